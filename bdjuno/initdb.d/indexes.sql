@@ -0,0 +1,17 @@
+-- Indexes required by likedao queries that are not created by the bdjuno schema
+
+-- Proposal search, the expression must be the same as proposalSearchDocument in graphql-server/pkg/queries/proposal.go.
+-- Substring matches are ORed with full-text matches, so they need trigram indexes for the indexes to be used at all
+CREATE INDEX IF NOT EXISTS proposal_search_document_index ON proposal USING GIN ((
+	setweight(to_tsvector('english', title), 'A') ||
+	setweight(to_tsvector('english', description), 'B')
+));
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX IF NOT EXISTS proposal_title_trgm_index ON proposal USING GIN (title gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS proposal_description_trgm_index ON proposal USING GIN (description gin_trgm_ops);
+
+-- Filtering blocks by time
+CREATE INDEX IF NOT EXISTS block_timestamp_index ON block (timestamp);
+
+-- Filtering transactions by involved address
+CREATE INDEX IF NOT EXISTS message_involved_accounts_addresses_index ON message USING GIN (involved_accounts_addresses);
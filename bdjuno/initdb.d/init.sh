@@ -20,4 +20,6 @@ psql -v ON_ERROR_STOP=1 --username "$DATABASE_USER" -c "CREATE SCHEMA \"$DATABAS
 
 for schema in /var/src/schemas/*.sql; do
 	psql -v ON_ERROR_STOP=1 --username "$DATABASE_USER" -f $schema $DATABASE_NAME;
-done
\ No newline at end of file
+done
+
+psql -v ON_ERROR_STOP=1 --username "$DATABASE_USER" -f /var/src/indexes.sql $DATABASE_NAME;
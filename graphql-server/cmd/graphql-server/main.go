@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -10,17 +11,47 @@ import (
 	sentrygin "github.com/getsentry/sentry-go/gin"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
 	"github.com/oursky/likedao/pkg/config"
 	"github.com/oursky/likedao/pkg/database"
 	"github.com/oursky/likedao/pkg/handlers"
 	"github.com/oursky/likedao/pkg/logging"
 	"github.com/oursky/likedao/pkg/middlewares"
+	"github.com/oursky/likedao/pkg/mutators"
+	"github.com/uptrace/bun"
 )
 
+const nonceCollectionInterval = 10 * time.Minute
+
+// collectExpiredNonces periodically removes consumed nonces that have expired, as expired nonces are rejected anyway
+func collectExpiredNonces(serverDB *bun.DB) {
+	ticker := time.NewTicker(nonceCollectionInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		ctx := context.Background()
+		logger := logging.GetLogger(ctx)
+
+		deleted, err := mutators.NewAuthNonceMutator(ctx, serverDB).DeleteExpiredNonces()
+		if err != nil {
+			logger.Errorf("failed to delete expired nonces: %v", err)
+			continue
+		}
+		if deleted > 0 {
+			logger.Infof("deleted %d expired nonces", deleted)
+		}
+	}
+}
+
 func main() {
 	config := config.LoadConfigFromEnv()
 	log.Printf("Using config: %v", config)
 
+	// Sign-in messages are checked against the allowed domains and chain IDs, no one could sign in without them
+	if len(config.Session.AllowedDomains) == 0 || len(config.Session.AllowedChainIDs) == 0 {
+		panic("SESSION_ALLOWED_DOMAINS and SESSION_ALLOWED_CHAIN_IDS must be set")
+	}
+
 	router := gin.Default()
 
 	if config.Log.Sentry != nil {
@@ -53,6 +84,8 @@ func main() {
 		panic(err)
 	}
 
+	go collectExpiredNonces(serverDB)
+
 	router.Use(cors.New(corsConfig))
 	router.Use(middlewares.Services(config, serverDB, chainDB))
 
@@ -68,10 +101,21 @@ func main() {
 			"message": "pong",
 		})
 	})
-	router.POST("/graphql", middlewares.Authentication(config), handlers.GraphqlHandler(serverDB, chainDB))
-	if gin.Mode() == gin.DebugMode {
-		router.GET("/graphql", handlers.GraphqlPlaygroundHandler())
-	}
+	graphqlHandler := handlers.GraphqlHandler(config, serverDB, chainDB)
+	playgroundHandler := handlers.GraphqlPlaygroundHandler()
+	router.POST("/graphql", middlewares.Authentication(config), graphqlHandler)
+	// Subscriptions are served over websocket on the same endpoint, queries and mutations are accepted over POST only
+	router.GET("/graphql", middlewares.Authentication(config), func(c *gin.Context) {
+		if websocket.IsWebSocketUpgrade(c.Request) {
+			graphqlHandler(c)
+			return
+		}
+		if gin.Mode() == gin.DebugMode {
+			playgroundHandler(c)
+			return
+		}
+		c.AbortWithStatus(http.StatusNotFound)
+	})
 
 	if err := router.Run(":8080"); err != nil && err != http.ErrServerClosed {
 		log.Fatalf("Listen: %s\n", err)
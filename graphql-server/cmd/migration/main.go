@@ -1,7 +1,9 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"strings"
@@ -9,6 +11,10 @@ import (
 	"github.com/oursky/likedao/migrations"
 	"github.com/oursky/likedao/pkg/config"
 	"github.com/oursky/likedao/pkg/database"
+	"github.com/oursky/likedao/pkg/models"
+	"github.com/oursky/likedao/pkg/mutators"
+	"github.com/oursky/likedao/pkg/queries"
+	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/extra/bundebug"
 	"github.com/uptrace/bun/migrate"
 	"github.com/urfave/cli/v2"
@@ -27,6 +33,8 @@ func main() {
 		Name: "bun",
 		Commands: []*cli.Command{
 			newDBCommand(migrate.NewMigrator(serverDB, migrations.Migrations)),
+			newISCNCommand(serverDB),
+			newValidatorCommand(config, serverDB),
 		},
 	}
 	if err := app.Run(os.Args); err != nil {
@@ -124,3 +132,106 @@ func newDBCommand(migrator *migrate.Migrator) *cli.Command {
 		},
 	}
 }
+
+func newISCNCommand(serverDB *bun.DB) *cli.Command {
+	return &cli.Command{
+		Name:  "iscn",
+		Usage: "ISCN records mirrored as posts",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "import",
+				Usage:     "import ISCN records from responses of the chain REST API, e.g. /iscn/records/id?iscn_id=...",
+				ArgsUsage: "<file, or - for stdin>",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return cli.Exit("exactly one file must be provided", 1)
+					}
+
+					var reader io.Reader = os.Stdin
+					if path := c.Args().First(); path != "-" {
+						file, err := os.Open(path)
+						if err != nil {
+							return err
+						}
+						defer file.Close()
+						reader = file
+					}
+
+					postMutator := mutators.NewPostMutator(c.Context, serverDB)
+					imported, skipped := 0, 0
+					decoder := json.NewDecoder(reader)
+					for decoder.More() {
+						var response models.ISCNRecordsResponse
+						if err := decoder.Decode(&response); err != nil {
+							return err
+						}
+
+						for _, record := range response.Records {
+							post, err := models.NewPostFromISCNRecord(response.Owner, record.Data)
+							if err != nil {
+								return err
+							}
+
+							// Posts are not updated by older versions of their records
+							res, err := postMutator.UpsertPost(post)
+							if err != nil {
+								return err
+							}
+							if res == nil {
+								skipped++
+								continue
+							}
+							imported++
+						}
+					}
+
+					fmt.Printf("imported %d records, skipped %d outdated records\n", imported, skipped)
+					return nil
+				},
+			},
+		},
+	}
+}
+
+func newValidatorCommand(config config.Config, serverDB *bun.DB) *cli.Command {
+	return &cli.Command{
+		Name:  "validator",
+		Usage: "validator history",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "snapshot",
+				Usage: "record the state of every validator at the latest block, to be run periodically, e.g. by a cron job",
+				Action: func(c *cli.Context) error {
+					chainDB, err := database.GetDB(config.ChainDatabase)
+					if err != nil {
+						return err
+					}
+
+					block, err := queries.NewBlockQuery(c.Context, chainDB).QueryLatestBlock()
+					if err != nil {
+						return err
+					}
+
+					validators, err := queries.NewValidatorQuery(c.Context, config, chainDB).QueryValidators()
+					if err != nil {
+						return err
+					}
+
+					snapshots := make([]models.ValidatorSnapshot, 0, len(validators))
+					for _, validator := range validators {
+						snapshots = append(snapshots, models.NewValidatorSnapshot(validator, *block))
+					}
+
+					// Snapshots already recorded at the height are skipped
+					created, err := mutators.NewValidatorSnapshotMutator(c.Context, serverDB).CreateSnapshots(snapshots)
+					if err != nil {
+						return err
+					}
+
+					fmt.Printf("created %d validator snapshots at height %d\n", created, block.Height)
+					return nil
+				},
+			},
+		},
+	}
+}
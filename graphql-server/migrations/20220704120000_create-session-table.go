@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/oursky/likedao/pkg/config"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	config := config.LoadConfigFromEnv()
+
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		values := FormatValues{
+			"schema": config.ServerDatabase.Schema,
+		}
+		err := db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+			_, err := tx.Exec(Format(`
+				CREATE TABLE IF NOT EXISTS {{.schema}}.session (
+					id TEXT PRIMARY KEY,
+					address TEXT NOT NULL,
+					user_agent TEXT NOT NULL DEFAULT '',
+					revoked BOOLEAN NOT NULL DEFAULT FALSE,
+					expires_at TIMESTAMP NOT NULL,
+					last_seen_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+				);
+
+				CREATE INDEX ix_session_address_revoked_expires_at ON {{.schema}}.session (address, revoked, expires_at);
+			`, values))
+			return err
+		})
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		values := FormatValues{
+			"schema": config.ServerDatabase.Schema,
+		}
+		err := db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+			_, err := tx.Exec(Format(`
+				DROP INDEX IF EXISTS {{.schema}}.ix_session_address_revoked_expires_at;
+				DROP TABLE IF EXISTS {{.schema}}.session;
+			`, values))
+			return err
+		})
+		return err
+	})
+}
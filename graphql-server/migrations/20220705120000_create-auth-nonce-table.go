@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/oursky/likedao/pkg/config"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	config := config.LoadConfigFromEnv()
+
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		values := FormatValues{
+			"schema": config.ServerDatabase.Schema,
+		}
+		err := db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+			_, err := tx.Exec(Format(`
+				CREATE TABLE IF NOT EXISTS {{.schema}}.auth_nonce (
+					id TEXT PRIMARY KEY,
+					nonce TEXT NOT NULL,
+					expires_at TIMESTAMP NOT NULL,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+				);
+
+				CREATE UNIQUE INDEX ix_auth_nonce_nonce ON {{.schema}}.auth_nonce (nonce);
+				CREATE INDEX ix_auth_nonce_expires_at ON {{.schema}}.auth_nonce (expires_at);
+			`, values))
+			return err
+		})
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		values := FormatValues{
+			"schema": config.ServerDatabase.Schema,
+		}
+		err := db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+			_, err := tx.Exec(Format(`
+				DROP INDEX IF EXISTS {{.schema}}.ix_auth_nonce_expires_at;
+				DROP INDEX IF EXISTS {{.schema}}.ix_auth_nonce_nonce;
+				DROP TABLE IF EXISTS {{.schema}}.auth_nonce;
+			`, values))
+			return err
+		})
+		return err
+	})
+}
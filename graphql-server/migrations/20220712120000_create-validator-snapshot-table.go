@@ -0,0 +1,54 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/oursky/likedao/pkg/config"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	config := config.LoadConfigFromEnv()
+
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		values := FormatValues{
+			"schema": config.ServerDatabase.Schema,
+		}
+		err := db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+			_, err := tx.Exec(Format(`
+				CREATE TABLE IF NOT EXISTS {{.schema}}.validator_snapshot (
+					id TEXT PRIMARY KEY,
+					consensus_address TEXT NOT NULL,
+					height BIGINT NOT NULL,
+					timestamp TIMESTAMP NOT NULL,
+					voting_power NUMERIC NOT NULL,
+					relative_voting_power DOUBLE PRECISION NOT NULL,
+					commission DOUBLE PRECISION NOT NULL,
+					missed_blocks_counter BIGINT NOT NULL,
+					uptime DOUBLE PRECISION NOT NULL,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+					UNIQUE (consensus_address, height)
+				);
+
+				CREATE INDEX ix_validator_snapshot_consensus_address_timestamp ON {{.schema}}.validator_snapshot (consensus_address, timestamp);
+			`, values))
+			return err
+		})
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		values := FormatValues{
+			"schema": config.ServerDatabase.Schema,
+		}
+		err := db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+			_, err := tx.Exec(Format(`
+				DROP INDEX IF EXISTS {{.schema}}.ix_validator_snapshot_consensus_address_timestamp;
+				DROP TABLE IF EXISTS {{.schema}}.validator_snapshot;
+			`, values))
+			return err
+		})
+		return err
+	})
+}
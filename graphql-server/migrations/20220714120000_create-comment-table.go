@@ -0,0 +1,69 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/oursky/likedao/pkg/config"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	config := config.LoadConfigFromEnv()
+
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		values := FormatValues{
+			"schema": config.ServerDatabase.Schema,
+		}
+		err := db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+			// Comments are stored in the server database, so reactions to comments target their IDs instead of ISCN IDs
+			_, err := tx.Exec(Format(`
+				CREATE TABLE IF NOT EXISTS {{.schema}}.comment (
+					id TEXT PRIMARY KEY,
+					proposal_id INTEGER NOT NULL,
+					parent_id TEXT REFERENCES {{.schema}}.comment (id),
+					author TEXT NOT NULL,
+					body TEXT NOT NULL,
+					edited_at TIMESTAMP,
+					deleted_at TIMESTAMP,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+				);
+
+				CREATE INDEX ix_comment_proposal_id_parent_id_id ON {{.schema}}.comment (proposal_id, parent_id, id);
+				CREATE INDEX ix_comment_parent_id_id ON {{.schema}}.comment (parent_id, id);
+
+				-- Reactions to comments could only target ISCN IDs before, they cannot refer to comments stored here
+				DELETE FROM {{.schema}}.reaction WHERE target_type = 'comment' AND target_id !~ '^[0-9a-z]{26}$';
+				ALTER TABLE {{.schema}}.reaction DROP CONSTRAINT chk_target;
+				ALTER TABLE {{.schema}}.reaction ADD CONSTRAINT chk_target CHECK (
+					(target_type = 'post' AND target_id ~ 'iscn://([-_.:=+,a-zA-Z0-9]+)/([-_.:=+,a-zA-Z0-9]+)(?:/([0-9]+))?$') OR
+					(target_type = 'comment' AND target_id ~ '^[0-9a-z]{26}$') OR
+					(target_type = 'proposal' AND target_id ~ '^[0-9]+$')
+				);
+			`, values))
+			return err
+		})
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		values := FormatValues{
+			"schema": config.ServerDatabase.Schema,
+		}
+		err := db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+			_, err := tx.Exec(Format(`
+				DELETE FROM {{.schema}}.reaction WHERE target_type = 'comment';
+				ALTER TABLE {{.schema}}.reaction DROP CONSTRAINT chk_target;
+				ALTER TABLE {{.schema}}.reaction ADD CONSTRAINT chk_target CHECK (
+					(target_type IN ('comment', 'post') AND target_id ~ 'iscn://([-_.:=+,a-zA-Z0-9]+)/([-_.:=+,a-zA-Z0-9]+)(?:/([0-9]+))?$') OR
+					(target_type = 'proposal' AND target_id ~ '^[0-9]+$')
+				);
+
+				DROP INDEX IF EXISTS {{.schema}}.ix_comment_parent_id_id;
+				DROP INDEX IF EXISTS {{.schema}}.ix_comment_proposal_id_parent_id_id;
+				DROP TABLE IF EXISTS {{.schema}}.comment;
+			`, values))
+			return err
+		})
+		return err
+	})
+}
@@ -0,0 +1,56 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/oursky/likedao/pkg/config"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	config := config.LoadConfigFromEnv()
+
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		values := FormatValues{
+			"schema": config.ServerDatabase.Schema,
+		}
+		err := db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+			// Posts are mirrored from ISCN records on chain, only the latest version of a record is kept
+			_, err := tx.Exec(Format(`
+				CREATE TABLE IF NOT EXISTS {{.schema}}.post (
+					id TEXT PRIMARY KEY,
+					iscn_id TEXT NOT NULL UNIQUE,
+					version INTEGER NOT NULL,
+					owner TEXT NOT NULL,
+					title TEXT,
+					description TEXT,
+					url TEXT,
+					content_fingerprints TEXT[] NOT NULL DEFAULT '{}',
+					proposal_id INTEGER,
+					record_timestamp TIMESTAMP NOT NULL,
+					record JSONB NOT NULL,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					CONSTRAINT chk_iscn_id CHECK (iscn_id ~ '^iscn://([-_.:=+,a-zA-Z0-9]+)/([-_.:=+,a-zA-Z0-9]+)$')
+				);
+
+				CREATE INDEX ix_post_proposal_id_record_timestamp_id ON {{.schema}}.post (proposal_id, record_timestamp, id);
+			`, values))
+			return err
+		})
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		values := FormatValues{
+			"schema": config.ServerDatabase.Schema,
+		}
+		err := db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+			_, err := tx.Exec(Format(`
+				DROP INDEX IF EXISTS {{.schema}}.ix_post_proposal_id_record_timestamp_id;
+				DROP TABLE IF EXISTS {{.schema}}.post;
+			`, values))
+			return err
+		})
+		return err
+	})
+}
@@ -0,0 +1,63 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/oursky/likedao/pkg/config"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	config := config.LoadConfigFromEnv()
+
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		values := FormatValues{
+			"schema": config.ServerDatabase.Schema,
+		}
+		err := db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+			// Catalogue of reactions allowed for each target type, seeded with the reactions of the frontend.
+			// Deprecated reactions cannot be set anymore but existing reactions are kept
+			_, err := tx.Exec(Format(`
+				CREATE TABLE IF NOT EXISTS {{.schema}}.reaction_kind (
+					target_type REACTION_TARGET_TYPE NOT NULL,
+					reaction TEXT NOT NULL CHECK (reaction ~ '^:\w+:$'),
+					emoji TEXT,
+					label TEXT NOT NULL,
+					position INTEGER NOT NULL DEFAULT 0,
+					deprecated BOOLEAN NOT NULL DEFAULT FALSE,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+					PRIMARY KEY (target_type, reaction)
+				);
+
+				INSERT INTO {{.schema}}.reaction_kind (target_type, reaction, emoji, label, position)
+				SELECT target_type, kind.reaction, kind.emoji, kind.label, kind.position
+				FROM unnest(enum_range(NULL::{{.schema}}.reaction_target_type)) AS target_type
+				CROSS JOIN (VALUES
+					(':likecoin:', NULL, 'LikeCoin', 0),
+					(':like:', '👍', 'Like', 1),
+					(':dislike:', '👎', 'Dislike', 2),
+					(':confused:', '😵‍💫', 'Confused', 3),
+					(':thinking:', '🤔', 'Thinking', 4),
+					(':bored:', '😴', 'Bored', 5),
+					(':whatever:', '🤷', 'Whatever', 6)
+				) AS kind (reaction, emoji, label, position);
+			`, values))
+			return err
+		})
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		values := FormatValues{
+			"schema": config.ServerDatabase.Schema,
+		}
+		err := db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+			_, err := tx.Exec(Format(`
+				DROP TABLE IF EXISTS {{.schema}}.reaction_kind;
+			`, values))
+			return err
+		})
+		return err
+	})
+}
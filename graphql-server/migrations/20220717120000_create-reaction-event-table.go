@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/oursky/likedao/pkg/config"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	config := config.LoadConfigFromEnv()
+
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		values := FormatValues{
+			"schema": config.ServerDatabase.Schema,
+		}
+		err := db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+			// Append-only history of reactions, reaction is null when a reaction is unset.
+			// Existing reactions are recorded as set at the time they were last updated
+			_, err := tx.Exec(Format(`
+				CREATE TABLE IF NOT EXISTS {{.schema}}.reaction_event (
+					id TEXT PRIMARY KEY,
+					address TEXT NOT NULL,
+					target_id TEXT NOT NULL,
+					target_type REACTION_TARGET_TYPE NOT NULL,
+					reaction TEXT,
+					previous_reaction TEXT,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+				);
+
+				CREATE INDEX ix_reaction_event_target_type_target_id_created_at ON {{.schema}}.reaction_event (target_type, target_id, created_at);
+
+				INSERT INTO {{.schema}}.reaction_event (id, address, target_id, target_type, reaction, created_at, updated_at)
+				SELECT id, address, target_id, target_type, reaction, updated_at, updated_at
+				FROM {{.schema}}.reaction;
+			`, values))
+			return err
+		})
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		values := FormatValues{
+			"schema": config.ServerDatabase.Schema,
+		}
+		err := db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+			_, err := tx.Exec(Format(`
+				DROP INDEX IF EXISTS {{.schema}}.ix_reaction_event_target_type_target_id_created_at;
+				DROP TABLE IF EXISTS {{.schema}}.reaction_event;
+			`, values))
+			return err
+		})
+		return err
+	})
+}
@@ -40,6 +40,12 @@ type SessionConfig struct {
 	SignatureSecret string
 	NonceExpiry     int
 	SessionExpiry   int
+	// Authorities (host[:port]) that sign-in messages are allowed to be issued for, required by the server
+	AllowedDomains []string
+	// Chain IDs that sign-in messages are allowed to be issued for, required by the server
+	AllowedChainIDs []string
+	// Maximum tolerated clock difference in seconds when checking the times in sign-in messages
+	MaxClockSkew int
 }
 
 type Config struct {
@@ -152,11 +158,35 @@ func LoadConfigFromEnv() Config {
 		cookieDomain = ""
 	}
 
+	maxClockSkewStr := os.Getenv("SESSION_MAX_CLOCK_SKEW")
+	if maxClockSkewStr == "" {
+		maxClockSkewStr = "60"
+	}
+	maxClockSkew, err := strconv.Atoi(maxClockSkewStr)
+	if err != nil {
+		maxClockSkew = 60
+	}
+
 	sessionConfig := SessionConfig{
 		CookieDomain:    cookieDomain,
 		SignatureSecret: os.Getenv("SIGNATURE_SECRET"),
 		SessionExpiry:   sessionExpiry,
 		NonceExpiry:     nonceExpiry,
+		AllowedDomains: func() []string {
+			allowedDomains := os.Getenv("SESSION_ALLOWED_DOMAINS")
+			if allowedDomains == "" {
+				return []string{}
+			}
+			return strings.Split(allowedDomains, ",")
+		}(),
+		AllowedChainIDs: func() []string {
+			allowedChainIDs := os.Getenv("SESSION_ALLOWED_CHAIN_IDS")
+			if allowedChainIDs == "" {
+				return []string{}
+			}
+			return strings.Split(allowedChainIDs, ",")
+		}(),
+		MaxClockSkew: maxClockSkew,
 	}
 
 	return Config{
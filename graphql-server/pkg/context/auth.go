@@ -5,7 +5,8 @@ import (
 )
 
 const (
-	AuthedUserContextKey contextKey = "AuthedUserContextKey"
+	AuthedUserContextKey    contextKey = "AuthedUserContextKey"
+	AuthedSessionContextKey contextKey = "AuthedSessionContextKey"
 )
 
 func NewRequestContextWithAuthedUser(
@@ -23,3 +24,21 @@ func GetAuthedUserAddress(ctx context.Context) string {
 	}
 	return address
 }
+
+func NewRequestContextWithAuthedSession(
+	ctx context.Context,
+	sessionID string,
+	address string,
+) context.Context {
+	ctx = context.WithValue(ctx, AuthedSessionContextKey, sessionID)
+	return NewRequestContextWithAuthedUser(ctx, address)
+}
+
+// Returns empty string when the user is not authenticated through a session, e.g. by debug header
+func GetAuthedSessionID(ctx context.Context) string {
+	sessionID, ok := ctx.Value(AuthedSessionContextKey).(string)
+	if !ok {
+		return ""
+	}
+	return sessionID
+}
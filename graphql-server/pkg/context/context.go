@@ -21,29 +21,45 @@ const (
 )
 
 type QueryContext struct {
-	Test          queries.ITestQuery
-	Block         queries.IBlockQuery
-	Chain         queries.IChainQuery
-	CommunityPool queries.ICommunityPoolQuery
-	Inflation     queries.IInflationQuery
-	StakingPool   queries.IStakingPoolQuery
-	Supply        queries.ISupplyQuery
-	Proposal      queries.IProposalQuery
-	Reaction      queries.IReactionQuery
-	Validator     queries.IValidatorQuery
+	Test              queries.ITestQuery
+	Block             queries.IBlockQuery
+	Chain             queries.IChainQuery
+	CommunityPool     queries.ICommunityPoolQuery
+	Inflation         queries.IInflationQuery
+	StakingPool       queries.IStakingPoolQuery
+	Supply            queries.ISupplyQuery
+	Proposal          queries.IProposalQuery
+	Reaction          queries.IReactionQuery
+	Validator         queries.IValidatorQuery
+	Session           queries.ISessionQuery
+	Account           queries.IAccountQuery
+	Transaction       queries.ITransactionQuery
+	Gov               queries.IGovQuery
+	ValidatorSnapshot queries.IValidatorSnapshotQuery
+	Comment           queries.ICommentQuery
+	Post              queries.IPostQuery
 }
 
 type MutatorContext struct {
-	Test     mutators.ITestMutator
-	Reaction mutators.IReactionMutator
+	Test      mutators.ITestMutator
+	Reaction  mutators.IReactionMutator
+	Session   mutators.ISessionMutator
+	AuthNonce mutators.IAuthNonceMutator
+	Comment   mutators.ICommentMutator
 }
 
 type DataLoaderContext struct {
-	Test      dataloaders.TestDataloader
-	Block     dataloaders.BlockDataloader
-	Proposal  dataloaders.ProposalDataloader
-	Reaction  dataloaders.ReactionDataloader
-	Validator dataloaders.ValidatorDataloader
+	Test        dataloaders.TestDataloader
+	Block       dataloaders.BlockDataloader
+	Chain       dataloaders.ChainDataloader
+	Proposal    dataloaders.ProposalDataloader
+	Reaction    dataloaders.ReactionDataloader
+	Validator   dataloaders.ValidatorDataloader
+	Account     dataloaders.AccountDataloader
+	Transaction dataloaders.TransactionDataloader
+	Comment     dataloaders.CommentDataloader
+	Post        dataloaders.PostDataloader
+	Gov         dataloaders.GovDataloader
 }
 
 type DatabaseContext struct {
@@ -58,27 +74,43 @@ func NewRequestContext(
 	config config.Config,
 ) context.Context {
 	queries := QueryContext{
-		Test:          queries.NewTestQuery(ctx, serverDB),
-		Block:         queries.NewBlockQuery(ctx, chainDB),
-		Chain:         queries.NewChainQuery(ctx, chainDB),
-		CommunityPool: queries.NewCommunityPoolQuery(ctx, chainDB),
-		Inflation:     queries.NewInflationQuery(ctx, chainDB),
-		StakingPool:   queries.NewStakingPoolQuery(ctx, chainDB),
-		Supply:        queries.NewSupplyQuery(ctx, chainDB),
-		Proposal:      queries.NewProposalQuery(ctx, config, chainDB),
-		Reaction:      queries.NewReactionQuery(ctx, serverDB),
-		Validator:     queries.NewValidatorQuery(ctx, config, chainDB),
+		Test:              queries.NewTestQuery(ctx, serverDB),
+		Block:             queries.NewBlockQuery(ctx, chainDB),
+		Chain:             queries.NewChainQuery(ctx, chainDB),
+		CommunityPool:     queries.NewCommunityPoolQuery(ctx, chainDB),
+		Inflation:         queries.NewInflationQuery(ctx, chainDB),
+		StakingPool:       queries.NewStakingPoolQuery(ctx, chainDB),
+		Supply:            queries.NewSupplyQuery(ctx, chainDB),
+		Proposal:          queries.NewProposalQuery(ctx, config, chainDB),
+		Reaction:          queries.NewReactionQuery(ctx, serverDB),
+		Validator:         queries.NewValidatorQuery(ctx, config, chainDB),
+		Session:           queries.NewSessionQuery(ctx, serverDB),
+		Account:           queries.NewAccountQuery(ctx, chainDB),
+		Transaction:       queries.NewTransactionQuery(ctx, chainDB),
+		Gov:               queries.NewGovQuery(ctx, chainDB),
+		ValidatorSnapshot: queries.NewValidatorSnapshotQuery(ctx, serverDB),
+		Comment:           queries.NewCommentQuery(ctx, serverDB),
+		Post:              queries.NewPostQuery(ctx, serverDB),
 	}
 	mutators := MutatorContext{
-		Test:     mutators.NewTestMutator(ctx, serverDB),
-		Reaction: mutators.NewReactionMutator(ctx, serverDB),
+		Test:      mutators.NewTestMutator(ctx, serverDB),
+		Reaction:  mutators.NewReactionMutator(ctx, serverDB),
+		Session:   mutators.NewSessionMutator(ctx, serverDB),
+		AuthNonce: mutators.NewAuthNonceMutator(ctx, serverDB),
+		Comment:   mutators.NewCommentMutator(ctx, serverDB),
 	}
 	dataLoaders := DataLoaderContext{
-		Test:      dataloaders.NewTestDataloader(queries.Test),
-		Block:     dataloaders.NewBlockDataloader(queries.Block),
-		Proposal:  dataloaders.NewProposalDataloader(queries.Proposal),
-		Reaction:  dataloaders.NewReactionDataloader(queries.Reaction),
-		Validator: dataloaders.NewValidatorDataloader(queries.Validator),
+		Test:        dataloaders.NewTestDataloader(queries.Test),
+		Block:       dataloaders.NewBlockDataloader(queries.Block),
+		Chain:       dataloaders.NewChainDataloader(queries.Chain),
+		Proposal:    dataloaders.NewProposalDataloader(queries.Proposal),
+		Reaction:    dataloaders.NewReactionDataloader(queries.Reaction, queries.Account),
+		Validator:   dataloaders.NewValidatorDataloader(queries.Validator),
+		Account:     dataloaders.NewAccountDataloader(queries.Account),
+		Transaction: dataloaders.NewTransactionDataloader(queries.Transaction),
+		Comment:     dataloaders.NewCommentDataloader(queries.Comment),
+		Post:        dataloaders.NewPostDataloader(queries.Post),
+		Gov:         dataloaders.NewGovDataloader(queries.Gov),
 	}
 
 	databases := DatabaseContext{
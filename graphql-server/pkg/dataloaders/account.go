@@ -0,0 +1,75 @@
+package dataloaders
+
+import (
+	godataloader "github.com/cychiuae/go-dataloader"
+	"github.com/oursky/likedao/pkg/models"
+	"github.com/oursky/likedao/pkg/queries"
+	"github.com/uptrace/bun/extra/bunbig"
+)
+
+type AccountDataloader interface {
+	LoadAccountByAddress(address string) (*models.Account, error)
+	LoadStakeByAddress(address string) (*bunbig.Int, error)
+}
+
+type AccountByAddressDataloader interface {
+	Load(address string) (*models.Account, error)
+	LoadAll(addresses []string) ([]*models.Account, []error)
+}
+
+type StakeByAddressDataloader interface {
+	Load(address string) (*bunbig.Int, error)
+	LoadAll(addresses []string) ([]*bunbig.Int, []error)
+}
+
+type IAccountDataloader struct {
+	accountByAddressLoader AccountByAddressDataloader
+	stakeByAddressLoader   StakeByAddressDataloader
+}
+
+func NewAccountDataloader(accountQuery queries.IAccountQuery) AccountDataloader {
+	accountByAddressLoader := godataloader.NewDataLoader(godataloader.DataLoaderConfig[string, *models.Account]{
+		Fetch: func(addresses []string) ([]*models.Account, []error) {
+			accounts, err := accountQuery.QueryAccountsByAddresses(addresses)
+			if err != nil {
+				errors := make([]error, 0, len(addresses))
+				for range addresses {
+					errors = append(errors, err)
+				}
+				return nil, errors
+			}
+			return accounts, nil
+		},
+		MaxBatch: DefaultMaxBatch,
+		Wait:     DefaultWait,
+	})
+
+	stakeByAddressLoader := godataloader.NewDataLoader(godataloader.DataLoaderConfig[string, *bunbig.Int]{
+		Fetch: func(addresses []string) ([]*bunbig.Int, []error) {
+			stakes, err := accountQuery.QueryStakesByAddresses(addresses)
+			if err != nil {
+				errors := make([]error, 0, len(addresses))
+				for range addresses {
+					errors = append(errors, err)
+				}
+				return nil, errors
+			}
+			return stakes, nil
+		},
+		MaxBatch: DefaultMaxBatch,
+		Wait:     DefaultWait,
+	})
+
+	return &IAccountDataloader{
+		accountByAddressLoader: accountByAddressLoader,
+		stakeByAddressLoader:   stakeByAddressLoader,
+	}
+}
+
+func (d *IAccountDataloader) LoadAccountByAddress(address string) (*models.Account, error) {
+	return d.accountByAddressLoader.Load(address)
+}
+
+func (d *IAccountDataloader) LoadStakeByAddress(address string) (*bunbig.Int, error) {
+	return d.stakeByAddressLoader.Load(address)
+}
@@ -0,0 +1,46 @@
+package dataloaders
+
+import (
+	godataloader "github.com/cychiuae/go-dataloader"
+	"github.com/oursky/likedao/pkg/models"
+	"github.com/oursky/likedao/pkg/queries"
+)
+
+type ChainDataloader interface {
+	LoadParamValue(key models.ParamKey) (*string, error)
+}
+
+type ParamValueDataloader interface {
+	Load(key models.ParamKey) (*string, error)
+	LoadAll(keys []models.ParamKey) ([]*string, []error)
+}
+
+type IChainDataloader struct {
+	paramValueLoader ParamValueDataloader
+}
+
+func NewChainDataloader(chainQuery queries.IChainQuery) ChainDataloader {
+	paramValueLoader := godataloader.NewDataLoader(godataloader.DataLoaderConfig[models.ParamKey, *string]{
+		MaxBatch: DefaultMaxBatch,
+		Wait:     DefaultWait,
+		Fetch: func(keys []models.ParamKey) ([]*string, []error) {
+			values, err := chainQuery.QueryParamValues(keys)
+			if err != nil {
+				errors := make([]error, 0, len(keys))
+				for range keys {
+					errors = append(errors, err)
+				}
+				return nil, errors
+			}
+			return values, nil
+		},
+	})
+
+	return &IChainDataloader{
+		paramValueLoader: paramValueLoader,
+	}
+}
+
+func (d *IChainDataloader) LoadParamValue(key models.ParamKey) (*string, error) {
+	return d.paramValueLoader.Load(key)
+}
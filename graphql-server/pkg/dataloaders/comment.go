@@ -0,0 +1,30 @@
+package dataloaders
+
+import (
+	godataloader "github.com/cychiuae/go-dataloader"
+	"github.com/oursky/likedao/pkg/models"
+	"github.com/oursky/likedao/pkg/queries"
+)
+
+type CommentDataloader interface {
+	Load(id string) (*models.Comment, error)
+	LoadAll(ids []string) ([]*models.Comment, []error)
+}
+
+func NewCommentDataloader(commentQuery queries.ICommentQuery) CommentDataloader {
+	return godataloader.NewDataLoader(godataloader.DataLoaderConfig[string, *models.Comment]{
+		Fetch: func(ids []string) ([]*models.Comment, []error) {
+			comments, err := commentQuery.QueryCommentsByIDs(ids)
+			if err != nil {
+				errors := make([]error, 0, len(ids))
+				for range ids {
+					errors = append(errors, err)
+				}
+				return nil, errors
+			}
+			return comments, nil
+		},
+		MaxBatch: DefaultMaxBatch,
+		Wait:     DefaultWait,
+	})
+}
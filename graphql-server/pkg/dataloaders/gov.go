@@ -0,0 +1,53 @@
+package dataloaders
+
+import (
+	godataloader "github.com/cychiuae/go-dataloader"
+	"github.com/oursky/likedao/pkg/models"
+	"github.com/oursky/likedao/pkg/queries"
+)
+
+type GovDataloader interface {
+	LoadGovParams() (*models.GovParams, error)
+}
+
+// There is only a single set of gov params, so all loads share the same key
+type govParamsKey struct{}
+
+type GovParamsDataloader interface {
+	Load(key govParamsKey) (*models.GovParams, error)
+}
+
+type IGovDataloader struct {
+	govParamsLoader GovParamsDataloader
+}
+
+func NewGovDataloader(govQuery queries.IGovQuery) GovDataloader {
+	govParamsLoader := godataloader.NewDataLoader(godataloader.DataLoaderConfig[govParamsKey, *models.GovParams]{
+		Fetch: func(keys []govParamsKey) ([]*models.GovParams, []error) {
+			params, err := govQuery.QueryGovParams()
+			if err != nil {
+				errors := make([]error, 0, len(keys))
+				for range keys {
+					errors = append(errors, err)
+				}
+				return nil, errors
+			}
+
+			result := make([]*models.GovParams, 0, len(keys))
+			for range keys {
+				result = append(result, params)
+			}
+			return result, nil
+		},
+		MaxBatch: DefaultMaxBatch,
+		Wait:     DefaultWait,
+	})
+
+	return &IGovDataloader{
+		govParamsLoader: govParamsLoader,
+	}
+}
+
+func (d *IGovDataloader) LoadGovParams() (*models.GovParams, error) {
+	return d.govParamsLoader.Load(govParamsKey{})
+}
@@ -0,0 +1,60 @@
+package dataloaders
+
+import (
+	godataloader "github.com/cychiuae/go-dataloader"
+	"github.com/oursky/likedao/pkg/models"
+	"github.com/oursky/likedao/pkg/queries"
+)
+
+type PostDataloader interface {
+	Load(id string) (*models.Post, error)
+	LoadAll(ids []string) ([]*models.Post, []error)
+	LoadPostByISCNID(iscnID string) (*models.Post, error)
+}
+
+type PostLoader interface {
+	Load(id string) (*models.Post, error)
+	LoadAll(ids []string) ([]*models.Post, []error)
+}
+
+type IPostDataloader struct {
+	postLoader         PostLoader
+	postByISCNIDLoader PostLoader
+}
+
+func newPostLoader(fetch func(ids []string) ([]*models.Post, error)) PostLoader {
+	return godataloader.NewDataLoader(godataloader.DataLoaderConfig[string, *models.Post]{
+		Fetch: func(ids []string) ([]*models.Post, []error) {
+			posts, err := fetch(ids)
+			if err != nil {
+				errors := make([]error, 0, len(ids))
+				for range ids {
+					errors = append(errors, err)
+				}
+				return nil, errors
+			}
+			return posts, nil
+		},
+		MaxBatch: DefaultMaxBatch,
+		Wait:     DefaultWait,
+	})
+}
+
+func NewPostDataloader(postQuery queries.IPostQuery) PostDataloader {
+	return &IPostDataloader{
+		postLoader:         newPostLoader(postQuery.QueryPostsByIDs),
+		postByISCNIDLoader: newPostLoader(postQuery.QueryPostsByISCNIDs),
+	}
+}
+
+func (d *IPostDataloader) Load(id string) (*models.Post, error) {
+	return d.postLoader.Load(id)
+}
+
+func (d *IPostDataloader) LoadAll(ids []string) ([]*models.Post, []error) {
+	return d.postLoader.LoadAll(ids)
+}
+
+func (d *IPostDataloader) LoadPostByISCNID(iscnID string) (*models.Post, error) {
+	return d.postByISCNIDLoader.Load(iscnID)
+}
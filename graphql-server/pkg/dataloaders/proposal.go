@@ -11,8 +11,11 @@ type ProposalDataloader interface {
 	LoadAll(ids []string) ([]*models.Proposal, []error)
 	LoadProposalTallyResult(id int) (*models.ProposalTallyResult, error)
 	LoadProposalTurnout(id int) (*float64, error)
+	LoadProposalStakingPoolSnapshot(id int) (*models.ProposalStakingPoolSnapshot, error)
 	LoadProposalVote(key models.ProposalVoteKey) (*models.ProposalVote, error)
+	LoadProposalVotes(keys []models.ProposalVoteKey) ([]*models.ProposalVote, []error)
 	LoadProposalDeposit(key models.ProposalDepositKey) (*models.ProposalDeposit, error)
+	LoadProposalVotingPowerBreakdown(id int) (*models.ProposalVotingPowerBreakdown, error)
 }
 
 type ProposalLoader interface {
@@ -30,6 +33,11 @@ type ProposalTurnoutDataloader interface {
 	LoadAll(ids []int) ([]*float64, []error)
 }
 
+type ProposalStakingPoolSnapshotDataloader interface {
+	Load(id int) (*models.ProposalStakingPoolSnapshot, error)
+	LoadAll(ids []int) ([]*models.ProposalStakingPoolSnapshot, []error)
+}
+
 type ProposalVoteDataloader interface {
 	Load(key models.ProposalVoteKey) (*models.ProposalVote, error)
 	LoadAll(keys []models.ProposalVoteKey) ([]*models.ProposalVote, []error)
@@ -40,12 +48,19 @@ type ProposalDepositDataloader interface {
 	LoadAll(keys []models.ProposalDepositKey) ([]*models.ProposalDeposit, []error)
 }
 
+type ProposalVotingPowerBreakdownDataloader interface {
+	Load(id int) (*models.ProposalVotingPowerBreakdown, error)
+	LoadAll(ids []int) ([]*models.ProposalVotingPowerBreakdown, []error)
+}
+
 type IProposalDataloader struct {
 	proposalLoader            ProposalLoader
 	proposalTallyResultLoader ProposalTallyResultDataloader
 	proposalTurnoutDataloader ProposalTurnoutDataloader
+	proposalSnapshotLoader    ProposalStakingPoolSnapshotDataloader
 	proposalVoteLoader        ProposalVoteDataloader
 	proposalDepositLoader     ProposalDepositDataloader
+	proposalBreakdownLoader   ProposalVotingPowerBreakdownDataloader
 }
 
 func NewProposalDataloader(proposalQuery queries.IProposalQuery) ProposalDataloader {
@@ -97,6 +112,22 @@ func NewProposalDataloader(proposalQuery queries.IProposalQuery) ProposalDataloa
 		Wait:     DefaultWait,
 	})
 
+	proposalSnapshotLoader := godataloader.NewDataLoader(godataloader.DataLoaderConfig[int, *models.ProposalStakingPoolSnapshot]{
+		Fetch: func(ids []int) ([]*models.ProposalStakingPoolSnapshot, []error) {
+			snapshots, err := proposalQuery.QueryProposalStakingPoolSnapshots(ids)
+			if err != nil {
+				errors := make([]error, 0, len(ids))
+				for range ids {
+					errors = append(errors, err)
+				}
+				return nil, errors
+			}
+			return snapshots, nil
+		},
+		MaxBatch: DefaultMaxBatch,
+		Wait:     DefaultWait,
+	})
+
 	proposalVoteLoader := godataloader.NewDataLoader(godataloader.DataLoaderConfig[models.ProposalVoteKey, *models.ProposalVote]{
 		MaxBatch: DefaultMaxBatch,
 		Wait:     DefaultWait,
@@ -129,12 +160,30 @@ func NewProposalDataloader(proposalQuery queries.IProposalQuery) ProposalDataloa
 		},
 	})
 
+	proposalBreakdownLoader := godataloader.NewDataLoader(godataloader.DataLoaderConfig[int, *models.ProposalVotingPowerBreakdown]{
+		Fetch: func(ids []int) ([]*models.ProposalVotingPowerBreakdown, []error) {
+			breakdowns, err := proposalQuery.QueryProposalVotingPowerBreakdowns(ids)
+			if err != nil {
+				errors := make([]error, 0, len(ids))
+				for range ids {
+					errors = append(errors, err)
+				}
+				return nil, errors
+			}
+			return breakdowns, nil
+		},
+		MaxBatch: DefaultMaxBatch,
+		Wait:     DefaultWait,
+	})
+
 	return &IProposalDataloader{
 		proposalLoader:            proposalLoader,
 		proposalTallyResultLoader: proposalTallyResultLoader,
 		proposalTurnoutDataloader: proposalTurnoutDataloader,
+		proposalSnapshotLoader:    proposalSnapshotLoader,
 		proposalVoteLoader:        proposalVoteLoader,
 		proposalDepositLoader:     proposalDepositLoader,
+		proposalBreakdownLoader:   proposalBreakdownLoader,
 	}
 }
 
@@ -154,10 +203,22 @@ func (d IProposalDataloader) LoadProposalTurnout(id int) (*float64, error) {
 	return d.proposalTurnoutDataloader.Load(id)
 }
 
+func (d IProposalDataloader) LoadProposalStakingPoolSnapshot(id int) (*models.ProposalStakingPoolSnapshot, error) {
+	return d.proposalSnapshotLoader.Load(id)
+}
+
 func (d IProposalDataloader) LoadProposalVote(key models.ProposalVoteKey) (*models.ProposalVote, error) {
 	return d.proposalVoteLoader.Load(key)
 }
 
+func (d IProposalDataloader) LoadProposalVotes(keys []models.ProposalVoteKey) ([]*models.ProposalVote, []error) {
+	return d.proposalVoteLoader.LoadAll(keys)
+}
+
 func (d IProposalDataloader) LoadProposalDeposit(key models.ProposalDepositKey) (*models.ProposalDeposit, error) {
 	return d.proposalDepositLoader.Load(key)
 }
+
+func (d IProposalDataloader) LoadProposalVotingPowerBreakdown(id int) (*models.ProposalVotingPowerBreakdown, error) {
+	return d.proposalBreakdownLoader.Load(id)
+}
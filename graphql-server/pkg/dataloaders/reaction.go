@@ -4,11 +4,16 @@ import (
 	godataloader "github.com/cychiuae/go-dataloader"
 	"github.com/oursky/likedao/pkg/models"
 	"github.com/oursky/likedao/pkg/queries"
+	"github.com/uptrace/bun/extra/bunbig"
 )
 
 type ReactionDataloader interface {
 	LoadProposalReactionCount(id int) ([]models.DBReactionCount, error)
 	LoadUserProposalReactions(key UserProposalReactionKey) (*models.Reaction, error)
+	LoadTargetReactionCount(key TargetReactionKey) ([]models.DBReactionCount, error)
+	LoadUserTargetReactions(key UserTargetReactionKey) (*models.Reaction, error)
+	LoadReactorAddresses(key ReactorAddressesKey) ([]string, error)
+	LoadReactorStake(key ReactorAddressesKey) (*bunbig.Int, error)
 }
 
 type ProposalReactionCountDataloader interface {
@@ -26,12 +31,52 @@ type UserProposalReactionDataloader interface {
 	LoadAll(keys []UserProposalReactionKey) ([]*models.Reaction, []error)
 }
 
+// TargetReactionKey identifies a target by its ID in reactions, which is not necessarily its node ID
+type TargetReactionKey struct {
+	TargetType models.ReactionTargetType
+	TargetID   string
+}
+
+type TargetReactionCountDataloader interface {
+	Load(key TargetReactionKey) ([]models.DBReactionCount, error)
+	LoadAll(keys []TargetReactionKey) ([][]models.DBReactionCount, []error)
+}
+
+type UserTargetReactionKey struct {
+	TargetReactionKey
+	UserAddress string
+}
+
+type UserTargetReactionDataloader interface {
+	Load(key UserTargetReactionKey) (*models.Reaction, error)
+	LoadAll(keys []UserTargetReactionKey) ([]*models.Reaction, []error)
+}
+
+type ReactorAddressesKey struct {
+	TargetReactionKey
+	Reaction string
+}
+
+type ReactorAddressesDataloader interface {
+	Load(key ReactorAddressesKey) ([]string, error)
+	LoadAll(keys []ReactorAddressesKey) ([][]string, []error)
+}
+
+type ReactorStakeDataloader interface {
+	Load(key ReactorAddressesKey) (*bunbig.Int, error)
+	LoadAll(keys []ReactorAddressesKey) ([]*bunbig.Int, []error)
+}
+
 type IReactionDataloader struct {
 	proposalReactionCountLoader ProposalReactionCountDataloader
 	userProposalReactionLoader  UserProposalReactionDataloader
+	targetReactionCountLoader   TargetReactionCountDataloader
+	userTargetReactionLoader    UserTargetReactionDataloader
+	reactorAddressesLoader      ReactorAddressesDataloader
+	reactorStakeLoader          ReactorStakeDataloader
 }
 
-func NewReactionDataloader(reactionQuery queries.IReactionQuery) ReactionDataloader {
+func NewReactionDataloader(reactionQuery queries.IReactionQuery, accountQuery queries.IAccountQuery) ReactionDataloader {
 	proposalReactionCountLoader := godataloader.NewDataLoader(godataloader.DataLoaderConfig[int, []models.DBReactionCount]{
 		MaxBatch: DefaultMaxBatch,
 		Wait:     DefaultWait,
@@ -88,9 +133,164 @@ func NewReactionDataloader(reactionQuery queries.IReactionQuery) ReactionDataloa
 		},
 	})
 
+	targetReactionCountLoader := godataloader.NewDataLoader(godataloader.DataLoaderConfig[TargetReactionKey, []models.DBReactionCount]{
+		MaxBatch: DefaultMaxBatch,
+		Wait:     DefaultWait,
+		Fetch: func(keys []TargetReactionKey) ([][]models.DBReactionCount, []error) {
+			typeToTargetIDs := make(map[models.ReactionTargetType][]string)
+			for _, key := range keys {
+				typeToTargetIDs[key.TargetType] = append(typeToTargetIDs[key.TargetType], key.TargetID)
+			}
+
+			keyToReactionCounts := make(map[TargetReactionKey][]models.DBReactionCount)
+			keyToErrors := make(map[TargetReactionKey]error)
+			for targetType, targetIDs := range typeToTargetIDs {
+				reactionCounts, err := reactionQuery.ScopeTargets(targetType, targetIDs).QueryTargetReactions()
+
+				for i, targetID := range targetIDs {
+					key := TargetReactionKey{TargetType: targetType, TargetID: targetID}
+					if err != nil {
+						keyToErrors[key] = err
+						continue
+					}
+					keyToReactionCounts[key] = reactionCounts[i]
+				}
+			}
+
+			result := make([][]models.DBReactionCount, 0, len(keys))
+			errors := make([]error, 0, len(keys))
+			for _, key := range keys {
+				result = append(result, keyToReactionCounts[key])
+				errors = append(errors, keyToErrors[key])
+			}
+
+			return result, errors
+		},
+	})
+
+	userTargetReactionLoader := godataloader.NewDataLoader(godataloader.DataLoaderConfig[UserTargetReactionKey, *models.Reaction]{
+		MaxBatch: DefaultMaxBatch,
+		Wait:     DefaultWait,
+		Fetch: func(keys []UserTargetReactionKey) ([]*models.Reaction, []error) {
+			type userTarget struct {
+				TargetType  models.ReactionTargetType
+				UserAddress string
+			}
+			userTargetToTargetIDs := make(map[userTarget][]string)
+			for _, key := range keys {
+				group := userTarget{TargetType: key.TargetType, UserAddress: key.UserAddress}
+				userTargetToTargetIDs[group] = append(userTargetToTargetIDs[group], key.TargetID)
+			}
+
+			keyToReaction := make(map[UserTargetReactionKey]*models.Reaction)
+			keyToErrors := make(map[UserTargetReactionKey]error)
+			for group, targetIDs := range userTargetToTargetIDs {
+				userTargetReactions, err := reactionQuery.ScopeTargets(group.TargetType, targetIDs).ScopeUserAddress(group.UserAddress).QueryUserReactions()
+
+				for i, targetID := range targetIDs {
+					key := UserTargetReactionKey{
+						TargetReactionKey: TargetReactionKey{TargetType: group.TargetType, TargetID: targetID},
+						UserAddress:       group.UserAddress,
+					}
+					if err != nil {
+						keyToErrors[key] = err
+						continue
+					}
+					keyToReaction[key] = userTargetReactions[i]
+				}
+			}
+
+			result := make([]*models.Reaction, 0, len(keys))
+			errors := make([]error, 0, len(keys))
+			for _, key := range keys {
+				result = append(result, keyToReaction[key])
+				errors = append(errors, keyToErrors[key])
+			}
+
+			return result, errors
+		},
+	})
+
+	// Reactions of a batch of keys are queried once for each target type
+	fetchReactorAddresses := func(keys []ReactorAddressesKey) ([][]string, []error) {
+		typeToTargetIDs := make(map[models.ReactionTargetType][]string)
+		for _, key := range keys {
+			typeToTargetIDs[key.TargetType] = append(typeToTargetIDs[key.TargetType], key.TargetID)
+		}
+
+		keyToAddresses := make(map[ReactorAddressesKey][]string)
+		typeToErrors := make(map[models.ReactionTargetType]error)
+		for targetType, targetIDs := range typeToTargetIDs {
+			reactions, err := reactionQuery.ScopeTargets(targetType, targetIDs).QueryReactions()
+			if err != nil {
+				typeToErrors[targetType] = err
+				continue
+			}
+
+			for _, reaction := range reactions {
+				key := ReactorAddressesKey{
+					TargetReactionKey: TargetReactionKey{TargetType: reaction.TargetType, TargetID: reaction.TargetID},
+					Reaction:          reaction.Reaction,
+				}
+				keyToAddresses[key] = append(keyToAddresses[key], reaction.Address)
+			}
+		}
+
+		result := make([][]string, 0, len(keys))
+		errors := make([]error, 0, len(keys))
+		for _, key := range keys {
+			addresses, exists := keyToAddresses[key]
+			if !exists {
+				addresses = []string{}
+			}
+			result = append(result, addresses)
+			errors = append(errors, typeToErrors[key.TargetType])
+		}
+
+		return result, errors
+	}
+
+	reactorAddressesLoader := godataloader.NewDataLoader(godataloader.DataLoaderConfig[ReactorAddressesKey, []string]{
+		MaxBatch: DefaultMaxBatch,
+		Wait:     DefaultWait,
+		Fetch:    fetchReactorAddresses,
+	})
+
+	// Reactions are stored in the server database and delegations in the chain database, so the stake of the reactors
+	// of all keys is summed by a single aggregate query over the reactor addresses
+	reactorStakeLoader := godataloader.NewDataLoader(godataloader.DataLoaderConfig[ReactorAddressesKey, *bunbig.Int]{
+		MaxBatch: DefaultMaxBatch,
+		Wait:     DefaultWait,
+		Fetch: func(keys []ReactorAddressesKey) ([]*bunbig.Int, []error) {
+			addresses, errors := fetchReactorAddresses(keys)
+
+			groups := make([][]string, 0, len(keys))
+			for i := range keys {
+				if errors[i] != nil {
+					groups = append(groups, []string{})
+					continue
+				}
+				groups = append(groups, addresses[i])
+			}
+
+			stakes, err := accountQuery.QueryTotalStakesByAddressGroups(groups)
+			if err != nil {
+				for i := range errors {
+					errors[i] = err
+				}
+				return nil, errors
+			}
+			return stakes, errors
+		},
+	})
+
 	return &IReactionDataloader{
 		proposalReactionCountLoader: proposalReactionCountLoader,
 		userProposalReactionLoader:  userProposalReactionLoader,
+		targetReactionCountLoader:   targetReactionCountLoader,
+		userTargetReactionLoader:    userTargetReactionLoader,
+		reactorAddressesLoader:      reactorAddressesLoader,
+		reactorStakeLoader:          reactorStakeLoader,
 	}
 }
 
@@ -101,3 +301,19 @@ func (d *IReactionDataloader) LoadProposalReactionCount(id int) ([]models.DBReac
 func (d *IReactionDataloader) LoadUserProposalReactions(key UserProposalReactionKey) (*models.Reaction, error) {
 	return d.userProposalReactionLoader.Load(key)
 }
+
+func (d *IReactionDataloader) LoadTargetReactionCount(key TargetReactionKey) ([]models.DBReactionCount, error) {
+	return d.targetReactionCountLoader.Load(key)
+}
+
+func (d *IReactionDataloader) LoadUserTargetReactions(key UserTargetReactionKey) (*models.Reaction, error) {
+	return d.userTargetReactionLoader.Load(key)
+}
+
+func (d *IReactionDataloader) LoadReactorAddresses(key ReactorAddressesKey) ([]string, error) {
+	return d.reactorAddressesLoader.Load(key)
+}
+
+func (d *IReactionDataloader) LoadReactorStake(key ReactorAddressesKey) (*bunbig.Int, error) {
+	return d.reactorStakeLoader.Load(key)
+}
@@ -0,0 +1,99 @@
+package dataloaders
+
+import (
+	godataloader "github.com/cychiuae/go-dataloader"
+	"github.com/oursky/likedao/pkg/models"
+	"github.com/oursky/likedao/pkg/queries"
+)
+
+type TransactionDataloader interface {
+	LoadTransactionByHash(hash string) (*models.Transaction, error)
+	LoadTransactionsByHeight(height int64) ([]models.Transaction, error)
+	LoadMessagesByTransactionHash(hash string) ([]models.TransactionMessage, error)
+}
+
+type TransactionByHashDataloader interface {
+	Load(hash string) (*models.Transaction, error)
+}
+
+type TransactionsByHeightDataloader interface {
+	Load(height int64) ([]models.Transaction, error)
+}
+
+type MessagesByTransactionHashDataloader interface {
+	Load(hash string) ([]models.TransactionMessage, error)
+}
+
+type ITransactionDataloader struct {
+	transactionByHashLoader         TransactionByHashDataloader
+	transactionsByHeightLoader      TransactionsByHeightDataloader
+	messagesByTransactionHashLoader MessagesByTransactionHashDataloader
+}
+
+func NewTransactionDataloader(transactionQuery queries.ITransactionQuery) TransactionDataloader {
+	transactionByHashLoader := godataloader.NewDataLoader(godataloader.DataLoaderConfig[string, *models.Transaction]{
+		Fetch: func(hashes []string) ([]*models.Transaction, []error) {
+			transactions, err := transactionQuery.QueryTransactionsByHashes(hashes)
+			if err != nil {
+				errors := make([]error, 0, len(hashes))
+				for range hashes {
+					errors = append(errors, err)
+				}
+				return nil, errors
+			}
+			return transactions, nil
+		},
+		MaxBatch: DefaultMaxBatch,
+		Wait:     DefaultWait,
+	})
+
+	transactionsByHeightLoader := godataloader.NewDataLoader(godataloader.DataLoaderConfig[int64, []models.Transaction]{
+		Fetch: func(heights []int64) ([][]models.Transaction, []error) {
+			transactions, err := transactionQuery.QueryTransactionsByHeights(heights)
+			if err != nil {
+				errors := make([]error, 0, len(heights))
+				for range heights {
+					errors = append(errors, err)
+				}
+				return nil, errors
+			}
+			return transactions, nil
+		},
+		MaxBatch: DefaultMaxBatch,
+		Wait:     DefaultWait,
+	})
+
+	messagesByTransactionHashLoader := godataloader.NewDataLoader(godataloader.DataLoaderConfig[string, []models.TransactionMessage]{
+		Fetch: func(hashes []string) ([][]models.TransactionMessage, []error) {
+			messages, err := transactionQuery.QueryMessagesByTransactionHashes(hashes)
+			if err != nil {
+				errors := make([]error, 0, len(hashes))
+				for range hashes {
+					errors = append(errors, err)
+				}
+				return nil, errors
+			}
+			return messages, nil
+		},
+		MaxBatch: DefaultMaxBatch,
+		Wait:     DefaultWait,
+	})
+
+	return &ITransactionDataloader{
+		transactionByHashLoader:         transactionByHashLoader,
+		transactionsByHeightLoader:      transactionsByHeightLoader,
+		messagesByTransactionHashLoader: messagesByTransactionHashLoader,
+	}
+}
+
+func (d *ITransactionDataloader) LoadTransactionByHash(hash string) (*models.Transaction, error) {
+	return d.transactionByHashLoader.Load(hash)
+}
+
+func (d *ITransactionDataloader) LoadTransactionsByHeight(height int64) ([]models.Transaction, error) {
+	return d.transactionsByHeightLoader.Load(height)
+}
+
+func (d *ITransactionDataloader) LoadMessagesByTransactionHash(hash string) ([]models.TransactionMessage, error) {
+	return d.messagesByTransactionHashLoader.Load(hash)
+}
@@ -8,21 +8,31 @@ import (
 
 type ValidatorDataloader interface {
 	LoadValidatorWithInfoByConsensusAddress(address string) (*models.Validator, error)
+	LoadValidatorsWithInfoByConsensusAddresses(addresses []string) ([]*models.Validator, []error)
 	LoadValidatorWithInfoBySelfDelegationAddress(address string) (*models.Validator, error)
+	LoadValidatorWithInfoByOperatorAddress(address string) (*models.Validator, error)
 	LoadRelativeTotalProposalCountByConsensusAddress(address string) (*int, error)
+	LoadGovernanceRecordByConsensusAddress(address string) (*models.ValidatorGovernanceRecord, error)
 }
 type ValidatorLoader interface {
 	Load(address string) (*models.Validator, error)
+	LoadAll(addresses []string) ([]*models.Validator, []error)
 }
 
 type ProposalCountLoader interface {
 	Load(address string) (*int, error)
 }
 
+type GovernanceRecordLoader interface {
+	Load(address string) (*models.ValidatorGovernanceRecord, error)
+}
+
 type IValidatorDataloader struct {
 	validatorConsensusAddressLoader                  ValidatorLoader
 	validatorSelfDelegationAddressLoader             ValidatorLoader
+	validatorOperatorAddressLoader                   ValidatorLoader
 	relativeTotalProposalCountConsensusAddressLoader ProposalCountLoader
+	governanceRecordConsensusAddressLoader           GovernanceRecordLoader
 }
 
 func NewValidatorDataloader(validatorQuery queries.IValidatorQuery) ValidatorDataloader {
@@ -58,6 +68,22 @@ func NewValidatorDataloader(validatorQuery queries.IValidatorQuery) ValidatorDat
 		},
 	})
 
+	validatorOperatorAddressLoader := godataloader.NewDataLoader(godataloader.DataLoaderConfig[string, *models.Validator]{
+		MaxBatch: DefaultMaxBatch,
+		Wait:     DefaultWait,
+		Fetch: func(addresses []string) ([]*models.Validator, []error) {
+			validators, err := validatorQuery.WithProposalVotes().WithProposalDeposits().QueryValidatorsByOperatorAddresses(addresses)
+			if err != nil {
+				errors := make([]error, 0, len(addresses))
+				for range addresses {
+					errors = append(errors, err)
+				}
+				return nil, errors
+			}
+			return validators, nil
+		},
+	})
+
 	relativeTotalProposalCountConsensusAddressLoader := godataloader.NewDataLoader(godataloader.DataLoaderConfig[string, *int]{
 		MaxBatch: DefaultMaxBatch,
 		Wait:     DefaultWait,
@@ -74,10 +100,28 @@ func NewValidatorDataloader(validatorQuery queries.IValidatorQuery) ValidatorDat
 		},
 	})
 
+	governanceRecordConsensusAddressLoader := godataloader.NewDataLoader(godataloader.DataLoaderConfig[string, *models.ValidatorGovernanceRecord]{
+		MaxBatch: DefaultMaxBatch,
+		Wait:     DefaultWait,
+		Fetch: func(addresses []string) ([]*models.ValidatorGovernanceRecord, []error) {
+			records, err := validatorQuery.QueryGovernanceRecords(addresses)
+			if err != nil {
+				errors := make([]error, 0, len(addresses))
+				for range addresses {
+					errors = append(errors, err)
+				}
+				return nil, errors
+			}
+			return records, nil
+		},
+	})
+
 	return &IValidatorDataloader{
 		validatorConsensusAddressLoader:                  validatorConsensusAddressLoader,
 		validatorSelfDelegationAddressLoader:             validatorSelfDelegationAddressLoader,
+		validatorOperatorAddressLoader:                   validatorOperatorAddressLoader,
 		relativeTotalProposalCountConsensusAddressLoader: relativeTotalProposalCountConsensusAddressLoader,
+		governanceRecordConsensusAddressLoader:           governanceRecordConsensusAddressLoader,
 	}
 }
 
@@ -85,10 +129,22 @@ func (d *IValidatorDataloader) LoadValidatorWithInfoByConsensusAddress(address s
 	return d.validatorConsensusAddressLoader.Load(address)
 }
 
+func (d *IValidatorDataloader) LoadValidatorsWithInfoByConsensusAddresses(addresses []string) ([]*models.Validator, []error) {
+	return d.validatorConsensusAddressLoader.LoadAll(addresses)
+}
+
 func (d *IValidatorDataloader) LoadValidatorWithInfoBySelfDelegationAddress(address string) (*models.Validator, error) {
 	return d.validatorSelfDelegationAddressLoader.Load(address)
 }
 
+func (d *IValidatorDataloader) LoadValidatorWithInfoByOperatorAddress(address string) (*models.Validator, error) {
+	return d.validatorOperatorAddressLoader.Load(address)
+}
+
 func (d *IValidatorDataloader) LoadRelativeTotalProposalCountByConsensusAddress(address string) (*int, error) {
 	return d.relativeTotalProposalCountConsensusAddressLoader.Load(address)
 }
+
+func (d *IValidatorDataloader) LoadGovernanceRecordByConsensusAddress(address string) (*models.ValidatorGovernanceRecord, error) {
+	return d.governanceRecordConsensusAddressLoader.Load(address)
+}
@@ -3,15 +3,17 @@ package handlers
 import (
 	"bytes"
 	"crypto/rand"
+	"database/sql"
 	"encoding/base64"
 	"encoding/hex"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/cosmos/cosmos-sdk/types/bech32"
 	"github.com/gin-gonic/gin"
 	"github.com/oursky/likedao/pkg/config"
+	pkgContext "github.com/oursky/likedao/pkg/context"
 	"github.com/oursky/likedao/pkg/models"
 )
 
@@ -23,7 +25,7 @@ const (
 //nolint:errcheck
 func NonceHandler(sessionConfig config.SessionConfig) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		nonceBytes := make([]byte, 4)
+		nonceBytes := make([]byte, 16)
 		if _, err := rand.Read(nonceBytes); err != nil {
 			c.Error(err)
 			return
@@ -31,6 +33,7 @@ func NonceHandler(sessionConfig config.SessionConfig) gin.HandlerFunc {
 		nonce := hex.EncodeToString(nonceBytes)
 		expiryTime := time.Now().Add(time.Duration(sessionConfig.NonceExpiry) * time.Second)
 
+		// The signed cookie proves the nonce is issued by the server, it is only recorded when consumed
 		encodedNonce := (&models.ExpirableValue{
 			Value:      nonce,
 			ExpiryTime: expiryTime,
@@ -70,19 +73,37 @@ func VerificationHandler(sessionConfig config.SessionConfig) gin.HandlerFunc {
 			return
 		}
 
-		data, err := base64.StdEncoding.DecodeString(body.SignDoc.Messages[0].Value.Data)
+		signedMessage, err := GetSignedMessage(&body)
 		if err != nil {
-			c.AbortWithError(400, fmt.Errorf("unable to decode data: %s", err))
+			c.AbortWithError(400, fmt.Errorf("sign doc is invalid: %s", err))
 			return
 		}
 
 		// Parse request body with ABNF
 		authenticationMsg := new(models.AuthenticationMessage)
-		if err := authenticationMsg.ParseAbnf(string(data)); err != nil {
+		if err := authenticationMsg.ParseAbnf(string(signedMessage.Data)); err != nil {
 			c.AbortWithError(400, fmt.Errorf("failed to parse authentication message: %s", err))
 			return
 		}
 
+		// Verify message is issued for this deployment
+		if authErr := VerifyAuthenticationMessagePolicy(sessionConfig, authenticationMsg, time.Now()); authErr != nil {
+			c.Error(authErr)
+			c.AbortWithStatusJSON(400, authErr)
+			return
+		}
+
+		// Verify sign doc is consistent with the message
+		if signedMessage.Signer != "" && signedMessage.Signer != authenticationMsg.Address {
+			c.AbortWithError(400, fmt.Errorf("signer does not match the authentication address"))
+			return
+		}
+
+		if signedMessage.ChainID != "" && signedMessage.ChainID != authenticationMsg.ChainID {
+			c.AbortWithError(400, fmt.Errorf("sign doc chain id does not match the authentication message"))
+			return
+		}
+
 		// Verify message ownership
 		pubKey, err := body.Signature.PubKey.ToPubKey()
 		if err != nil {
@@ -114,23 +135,39 @@ func VerificationHandler(sessionConfig config.SessionConfig) gin.HandlerFunc {
 			return
 		}
 
-		marshalledMessage, err := json.Marshal(body.SignDoc)
-		if err != nil {
-			c.AbortWithError(400, fmt.Errorf("failed to verify signature: %s", err))
+		sigValid := pubKey.VerifySignature(signedMessage.SignBytes, decodedSignature)
+
+		if !sigValid {
+			c.AbortWithError(400, fmt.Errorf("signature invalid"))
 			return
 		}
 
-		sigValid := pubKey.VerifySignature(marshalledMessage, decodedSignature)
+		// Consume nonce, rejecting it if it has already been used
+		consumed, err := pkgContext.GetMutatorsFromCtx(c.Request.Context()).AuthNonce.ConsumeNonce(nonce.Value, nonce.ExpiryTime)
+		if err != nil {
+			c.AbortWithError(500, fmt.Errorf("failed to consume nonce: %s", err))
+			return
+		}
+		if !consumed {
+			c.AbortWithError(400, fmt.Errorf("nonce is either missing or invalid"))
+			return
+		}
 
-		if !sigValid {
-			c.AbortWithError(400, fmt.Errorf("signature invalid"))
+		// Persist session
+		expiryDate := time.Now().Add(time.Duration(sessionConfig.SessionExpiry) * time.Second)
+		session, err := pkgContext.GetMutatorsFromCtx(c.Request.Context()).Session.CreateSession(
+			authenticationMsg.Address,
+			c.Request.UserAgent(),
+			expiryDate,
+		)
+		if err != nil {
+			c.AbortWithError(500, fmt.Errorf("failed to create session: %s", err))
 			return
 		}
 
 		// Sign session token
-		expiryDate := time.Now().Add(time.Duration(sessionConfig.SessionExpiry) * time.Second)
 		sessionToken := (&models.ExpirableValue{
-			Value:      authenticationMsg.Address,
+			Value:      session.ID,
 			ExpiryTime: expiryDate,
 		}).Encode()
 
@@ -146,6 +183,15 @@ func VerificationHandler(sessionConfig config.SessionConfig) gin.HandlerFunc {
 //nolint:errcheck
 func LogoutHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// Revoke session so that the token cannot be reused
+		if session, err := GetSessionFromCookie(c); err == nil {
+			_, err := pkgContext.GetMutatorsFromCtx(c.Request.Context()).Session.RevokeSession(session.ID, session.Address)
+			if err != nil {
+				c.AbortWithError(500, fmt.Errorf("failed to revoke session: %s", err))
+				return
+			}
+		}
+
 		// Remove session token
 		RemoveSignedCookie(c, SessionCookieName, "/")
 
@@ -162,25 +208,18 @@ func ValidateHandler() gin.HandlerFunc {
 			return
 		}
 
-		sessionToken, err := GetSignedCookie(c, SessionCookieName)
-		if err != nil {
-			c.AbortWithError(400, fmt.Errorf("session token is either missing or invalid: %s", err))
-			return
-		}
-
-		sessionTokenData := new(models.ExpirableValue)
-		if err := sessionTokenData.ParseString(sessionToken); err != nil {
-			c.AbortWithError(400, fmt.Errorf("session token is either missing or invalid: %s", err))
+		session, err := GetSessionFromCookie(c)
+		if errors.Is(err, ErrSessionInactive) {
+			RemoveSignedCookie(c, SessionCookieName, "/")
+			c.AbortWithError(401, err)
 			return
 		}
-
-		if sessionTokenData.IsExpired() {
-			RemoveSignedCookie(c, SessionCookieName, "/")
-			c.AbortWithError(401, fmt.Errorf("session token has expired"))
+		if err != nil {
+			c.AbortWithError(400, err)
 			return
 		}
 
-		if sessionTokenData.Value != body.Address {
+		if session.Address != body.Address {
 			RemoveSignedCookie(c, SessionCookieName, "/")
 			c.AbortWithError(401, fmt.Errorf("session token does not match the address"))
 			return
@@ -189,3 +228,37 @@ func ValidateHandler() gin.HandlerFunc {
 		c.Status(200)
 	}
 }
+
+var ErrSessionInactive = errors.New("session has expired or been revoked")
+
+// GetSessionFromCookie verifies the session token cookie and returns the session it refers to,
+// ErrSessionInactive is returned when the session can no longer be used
+func GetSessionFromCookie(c *gin.Context) (*models.Session, error) {
+	sessionToken, err := GetSignedCookie(c, SessionCookieName)
+	if err != nil {
+		return nil, fmt.Errorf("session token is either missing or invalid: %s", err)
+	}
+
+	sessionTokenData := new(models.ExpirableValue)
+	if err := sessionTokenData.ParseString(sessionToken); err != nil {
+		return nil, fmt.Errorf("session token is either missing or invalid: %s", err)
+	}
+
+	if sessionTokenData.IsExpired() {
+		return nil, ErrSessionInactive
+	}
+
+	session, err := pkgContext.GetQueriesFromCtx(c.Request.Context()).Session.QuerySessionByID(sessionTokenData.Value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSessionInactive
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load session: %s", err)
+	}
+
+	if !session.IsActive() {
+		return nil, ErrSessionInactive
+	}
+
+	return session, nil
+}
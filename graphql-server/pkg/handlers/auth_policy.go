@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/oursky/likedao/pkg/config"
+	"github.com/oursky/likedao/pkg/models"
+)
+
+type AuthErrorCode string
+
+const (
+	AuthErrorDomainNotAllowed   AuthErrorCode = "DOMAIN_NOT_ALLOWED"
+	AuthErrorURIMismatch        AuthErrorCode = "URI_MISMATCH"
+	AuthErrorChainIDNotAllowed  AuthErrorCode = "CHAIN_ID_NOT_ALLOWED"
+	AuthErrorIssuedAtInvalid    AuthErrorCode = "ISSUED_AT_INVALID"
+	AuthErrorMessageExpired     AuthErrorCode = "MESSAGE_EXPIRED"
+	AuthErrorMessageNotYetValid AuthErrorCode = "MESSAGE_NOT_YET_VALID"
+)
+
+type AuthError struct {
+	Code    AuthErrorCode `json:"code"`
+	Message string        `json:"message"`
+}
+
+func (e *AuthError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+func newAuthError(code AuthErrorCode, format string, args ...interface{}) *AuthError {
+	return &AuthError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// VerifyAuthenticationMessagePolicy checks the fields of a parsed EIP-4361 message against the session config,
+// so that a message signed for another site, chain or time window cannot be replayed against this deployment
+func VerifyAuthenticationMessagePolicy(sessionConfig config.SessionConfig, message *models.AuthenticationMessage, now time.Time) *AuthError {
+	if !containsFold(sessionConfig.AllowedDomains, message.Authority) {
+		return newAuthError(AuthErrorDomainNotAllowed, "domain %s is not allowed", message.Authority)
+	}
+
+	// The URI must refer to a resource within the authority requesting the sign in
+	uri, err := url.Parse(message.URI)
+	if err != nil || !strings.EqualFold(uri.Host, message.Authority) {
+		return newAuthError(AuthErrorURIMismatch, "uri %s does not match domain %s", message.URI, message.Authority)
+	}
+
+	if !containsFold(sessionConfig.AllowedChainIDs, message.ChainID) {
+		return newAuthError(AuthErrorChainIDNotAllowed, "chain id %s is not allowed", message.ChainID)
+	}
+
+	// Message is issued after a nonce is requested, so it cannot be older than the nonce
+	maxClockSkew := time.Duration(sessionConfig.MaxClockSkew) * time.Second
+	nonceExpiry := time.Duration(sessionConfig.NonceExpiry) * time.Second
+	if message.IssuedAt.After(now.Add(maxClockSkew)) || message.IssuedAt.Before(now.Add(-nonceExpiry-maxClockSkew)) {
+		return newAuthError(AuthErrorIssuedAtInvalid, "issued at %s is out of the accepted range", message.IssuedAt.Format(time.RFC3339))
+	}
+
+	// Clock skew only loosens the start of the validity window, an expiry set by the signer is honoured as is
+	if !message.ExpirationAt.IsZero() && !now.Before(message.ExpirationAt) {
+		return newAuthError(AuthErrorMessageExpired, "message expired at %s", message.ExpirationAt.Format(time.RFC3339))
+	}
+
+	if !message.NotBefore.IsZero() && now.Add(maxClockSkew).Before(message.NotBefore) {
+		return newAuthError(AuthErrorMessageNotYetValid, "message is not valid before %s", message.NotBefore.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package handlers_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oursky/likedao/pkg/config"
+	"github.com/oursky/likedao/pkg/handlers"
+	"github.com/oursky/likedao/pkg/models"
+)
+
+func Test_VerifyAuthenticationMessagePolicy(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	sessionConfig := config.SessionConfig{
+		NonceExpiry:     300,
+		AllowedDomains:  []string{"likedao.com", "localhost:3000"},
+		AllowedChainIDs: []string{"likecoin-mainnet-2"},
+		MaxClockSkew:    60,
+	}
+	newMessage := func() *models.AuthenticationMessage {
+		return &models.AuthenticationMessage{
+			Authority: "likedao.com",
+			URI:       "https://likedao.com",
+			ChainID:   "likecoin-mainnet-2",
+			IssuedAt:  now.Add(-10 * time.Second),
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		message := newMessage()
+		message.ExpirationAt = now.Add(time.Minute)
+		message.NotBefore = now.Add(-time.Minute)
+		if err := handlers.VerifyAuthenticationMessagePolicy(sessionConfig, message, now); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("Success with port", func(t *testing.T) {
+		message := newMessage()
+		message.Authority = "localhost:3000"
+		message.URI = "http://localhost:3000"
+		if err := handlers.VerifyAuthenticationMessagePolicy(sessionConfig, message, now); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	testCases := []struct {
+		name     string
+		modify   func(message *models.AuthenticationMessage)
+		expected handlers.AuthErrorCode
+	}{
+		{"Domain not allowed", func(m *models.AuthenticationMessage) {
+			m.Authority = "evil.com"
+			m.URI = "https://evil.com"
+		}, handlers.AuthErrorDomainNotAllowed},
+		{"URI mismatch", func(m *models.AuthenticationMessage) {
+			m.URI = "https://evil.com/likedao.com"
+		}, handlers.AuthErrorURIMismatch},
+		{"Chain ID not allowed", func(m *models.AuthenticationMessage) {
+			m.ChainID = "cosmoshub-4"
+		}, handlers.AuthErrorChainIDNotAllowed},
+		{"Issued in the future", func(m *models.AuthenticationMessage) {
+			m.IssuedAt = now.Add(2 * time.Minute)
+		}, handlers.AuthErrorIssuedAtInvalid},
+		{"Issued before nonce expiry", func(m *models.AuthenticationMessage) {
+			m.IssuedAt = now.Add(-10 * time.Minute)
+		}, handlers.AuthErrorIssuedAtInvalid},
+		{"Expired", func(m *models.AuthenticationMessage) {
+			m.ExpirationAt = now.Add(-2 * time.Minute)
+		}, handlers.AuthErrorMessageExpired},
+		{"Expired within clock skew", func(m *models.AuthenticationMessage) {
+			m.ExpirationAt = now.Add(-time.Second)
+		}, handlers.AuthErrorMessageExpired},
+		{"Not yet valid", func(m *models.AuthenticationMessage) {
+			m.NotBefore = now.Add(2 * time.Minute)
+		}, handlers.AuthErrorMessageNotYetValid},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			message := newMessage()
+			testCase.modify(message)
+			err := handlers.VerifyAuthenticationMessagePolicy(sessionConfig, message, now)
+			if err == nil {
+				t.Fatalf("expected %s, got no error", testCase.expected)
+			}
+			if err.Code != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, err.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/oursky/likedao/pkg/models"
+)
+
+const MsgSignDataType = "sign/MsgSignData"
+
+// SignedMessage is the sign-in message carried by a sign doc, along with the bytes covered by the signature
+type SignedMessage struct {
+	Data []byte
+	// Signer declared in the sign doc, empty if the sign doc does not declare one
+	Signer string
+	// Chain ID declared in the sign doc, empty for ADR-036 documents
+	ChainID   string
+	SignBytes []byte
+}
+
+// GetSignedMessage extracts the sign-in message from either an amino or a direct sign doc
+func GetSignedMessage(body *models.AuthenticationRequestData) (*SignedMessage, error) {
+	switch {
+	case body.SignDoc != nil && body.DirectSignDoc != nil:
+		return nil, errors.New("only one of sign_doc and direct_sign_doc can be provided")
+	case body.SignDoc != nil:
+		return getAminoSignedMessage(body.SignDoc)
+	case body.DirectSignDoc != nil:
+		return getDirectSignedMessage(body.DirectSignDoc)
+	}
+	return nil, errors.New("sign doc is missing")
+}
+
+// getAminoSignedMessage handles ADR-036 offline signing documents, which are signed in
+// legacy amino JSON mode by Keplr, Leap, Cosmostation and Ledger devices
+// See https://github.com/cosmos/cosmos-sdk/blob/main/docs/architecture/adr-036-arbitrary-signature.md
+func getAminoSignedMessage(signDoc *models.SignDoc) (*SignedMessage, error) {
+	if len(signDoc.Messages) != 1 {
+		return nil, fmt.Errorf("sign doc must contain exactly 1 message, got %d", len(signDoc.Messages))
+	}
+
+	msg := signDoc.Messages[0]
+	if msg.Type != MsgSignDataType {
+		return nil, fmt.Errorf("unexpected message type: %s", msg.Type)
+	}
+
+	if signDoc.ChainID != "" ||
+		signDoc.AccountNumber != "0" ||
+		signDoc.Sequence != "0" ||
+		signDoc.Memo != "" ||
+		signDoc.Fee.Gas != "0" ||
+		len(signDoc.Fee.Amount) != 0 {
+		return nil, errors.New("sign doc is not a valid ADR-036 document")
+	}
+
+	data, err := base64.StdEncoding.DecodeString(msg.Value.Data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode data: %s", err)
+	}
+
+	// Amino JSON sign bytes are the compact JSON of the sign doc with keys sorted
+	marshalledSignDoc, err := json.Marshal(signDoc)
+	if err != nil {
+		return nil, err
+	}
+	signBytes, err := sdk.SortJSON(marshalledSignDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedMessage{
+		Data:      data,
+		Signer:    msg.Value.Signer,
+		SignBytes: signBytes,
+	}, nil
+}
+
+// getDirectSignedMessage handles protobuf sign docs, in which the sign-in message is carried in the memo
+// of a transaction body without any messages so that the signed document can never be broadcast
+func getDirectSignedMessage(signDoc *models.DirectSignDoc) (*SignedMessage, error) {
+	bodyBytes, err := base64.StdEncoding.DecodeString(signDoc.BodyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode body bytes: %s", err)
+	}
+
+	authInfoBytes, err := base64.StdEncoding.DecodeString(signDoc.AuthInfoBytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode auth info bytes: %s", err)
+	}
+
+	accountNumber, err := strconv.ParseUint(signDoc.AccountNumber, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account number: %s", err)
+	}
+
+	var body txtypes.TxBody
+	if err := body.Unmarshal(bodyBytes); err != nil {
+		return nil, fmt.Errorf("unable to decode body: %s", err)
+	}
+
+	if len(body.Messages) != 0 {
+		return nil, fmt.Errorf("sign doc must not contain any messages, got %d", len(body.Messages))
+	}
+
+	if body.Memo == "" {
+		return nil, errors.New("sign doc memo is empty")
+	}
+
+	signBytes, err := (&txtypes.SignDoc{
+		BodyBytes:     bodyBytes,
+		AuthInfoBytes: authInfoBytes,
+		ChainId:       signDoc.ChainID,
+		AccountNumber: accountNumber,
+	}).Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedMessage{
+		Data:      []byte(body.Memo),
+		ChainID:   signDoc.ChainID,
+		SignBytes: signBytes,
+	}, nil
+}
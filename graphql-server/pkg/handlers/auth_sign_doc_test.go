@@ -0,0 +1,93 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/oursky/likedao/pkg/handlers"
+	"github.com/oursky/likedao/pkg/models"
+)
+
+func Test_GetSignedMessage(t *testing.T) {
+	signer := "like1ewpwp3f5gl3u7gjlmnkd2hnvdw8ygmd0cp4y5r"
+	data := base64.StdEncoding.EncodeToString([]byte("message"))
+	newSignDoc := func() *models.SignDoc {
+		return &models.SignDoc{
+			AccountNumber: "0",
+			ChainID:       "",
+			Fee:           models.Fee{Amount: []models.Coin{}, Gas: "0"},
+			Memo:          "",
+			Messages: []models.Message{{
+				Type:  handlers.MsgSignDataType,
+				Value: models.MessageSignData{Data: data, Signer: signer},
+			}},
+			Sequence: "0",
+		}
+	}
+
+	t.Run("Amino sign bytes are sorted canonical JSON", func(t *testing.T) {
+		res, err := handlers.GetSignedMessage(&models.AuthenticationRequestData{SignDoc: newSignDoc()})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		expected := `{"account_number":"0","chain_id":"","fee":{"amount":[],"gas":"0"},"memo":"",` +
+			`"msgs":[{"type":"sign/MsgSignData","value":{"data":"` + data + `","signer":"` + signer + `"}}],"sequence":"0"}`
+		if string(res.SignBytes) != expected {
+			t.Errorf("expected sign bytes %s, got %s", expected, res.SignBytes)
+		}
+		if string(res.Data) != "message" || res.Signer != signer {
+			t.Errorf("unexpected signed message %+v", res)
+		}
+	})
+
+	t.Run("Fail with empty messages", func(t *testing.T) {
+		signDoc := newSignDoc()
+		signDoc.Messages = []models.Message{}
+		if _, err := handlers.GetSignedMessage(&models.AuthenticationRequestData{SignDoc: signDoc}); err == nil {
+			t.Errorf("expected error, got nil")
+		}
+	})
+
+	t.Run("Fail with non ADR-036 document", func(t *testing.T) {
+		signDoc := newSignDoc()
+		signDoc.ChainID = "likecoin-mainnet-2"
+		if _, err := handlers.GetSignedMessage(&models.AuthenticationRequestData{SignDoc: signDoc}); err == nil {
+			t.Errorf("expected error, got nil")
+		}
+	})
+
+	t.Run("Fail without sign doc", func(t *testing.T) {
+		if _, err := handlers.GetSignedMessage(&models.AuthenticationRequestData{}); err == nil {
+			t.Errorf("expected error, got nil")
+		}
+	})
+
+	t.Run("Direct sign doc carries message in memo", func(t *testing.T) {
+		bodyBytes, err := (&txtypes.TxBody{Memo: "message"}).Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := handlers.GetSignedMessage(&models.AuthenticationRequestData{
+			DirectSignDoc: &models.DirectSignDoc{
+				BodyBytes:     base64.StdEncoding.EncodeToString(bodyBytes),
+				AuthInfoBytes: "",
+				ChainID:       "likecoin-mainnet-2",
+				AccountNumber: "0",
+			},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		expected, err := (&txtypes.SignDoc{BodyBytes: bodyBytes, AuthInfoBytes: []byte{}, ChainId: "likecoin-mainnet-2"}).Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(res.SignBytes, expected) || string(res.Data) != "message" || res.ChainID != "likecoin-mainnet-2" {
+			t.Errorf("unexpected signed message %+v", res)
+		}
+	})
+}
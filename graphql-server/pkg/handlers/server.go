@@ -2,10 +2,18 @@ package handlers
 
 import (
 	"context"
+	"net/http"
+	"time"
 
 	gql "github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/oursky/likedao/pkg/config"
+	pkgContext "github.com/oursky/likedao/pkg/context"
 	"github.com/oursky/likedao/pkg/directives"
 	"github.com/oursky/likedao/pkg/errors"
 	"github.com/oursky/likedao/pkg/generated/graphql"
@@ -42,11 +50,38 @@ func (GraphQLOperationLogger) InterceptResponse(ctx context.Context, next gql.Re
 	return next(ctx)
 }
 
-func GraphqlHandler(serverDB *bun.DB, chainDB *bun.DB) gin.HandlerFunc {
-	c := graphql.Config{Resolvers: &resolvers.Resolver{ServerDB: serverDB, ChainDB: chainDB}}
+func GraphqlHandler(config config.Config, serverDB *bun.DB, chainDB *bun.DB) gin.HandlerFunc {
+	c := graphql.Config{Resolvers: resolvers.NewResolver(config, serverDB, chainDB)}
 	c.Directives.Authed = directives.Authed
 
-	h := handler.NewDefaultServer(graphql.NewExecutableSchema(c))
+	sessionWatcher := newSessionWatcher(serverDB)
+
+	h := handler.New(graphql.NewExecutableSchema(c))
+	h.AddTransport(transport.Websocket{
+		KeepAlivePingInterval: 10 * time.Second,
+		Upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool {
+				return isOriginAllowed(config.Cors.AllowOrigins, r.Header.Get("Origin"))
+			},
+		},
+		// Connections are authenticated once on upgrade, so they are closed when the session is revoked afterwards
+		InitFunc: func(ctx context.Context, initPayload transport.InitPayload) (context.Context, error) {
+			if sessionID := pkgContext.GetAuthedSessionID(ctx); sessionID != "" {
+				return sessionWatcher.Watch(ctx, sessionID), nil
+			}
+			return ctx, nil
+		},
+	})
+	h.AddTransport(transport.Options{})
+	h.AddTransport(transport.POST{})
+	h.AddTransport(transport.MultipartForm{})
+
+	h.SetQueryCache(lru.New(1000))
+
+	h.Use(extension.Introspection{})
+	h.Use(extension.AutomaticPersistedQuery{
+		Cache: lru.New(100),
+	})
 	h.Use(GraphQLOperationLogger{})
 
 	h.SetErrorPresenter(errors.DefaultErrorPresenter)
@@ -56,3 +91,18 @@ func GraphqlHandler(serverDB *bun.DB, chainDB *bun.DB) gin.HandlerFunc {
 		h.ServeHTTP(c.Writer, c.Request)
 	}
 }
+
+// Browsers do not apply CORS to websocket handshakes, so origins of subscriptions are checked against the CORS config
+func isOriginAllowed(allowOrigins []string, origin string) bool {
+	// Non-browser clients do not send an origin
+	if origin == "" {
+		return true
+	}
+
+	for _, allowOrigin := range allowOrigins {
+		if allowOrigin == "*" || allowOrigin == origin {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/oursky/likedao/pkg/logging"
+	"github.com/oursky/likedao/pkg/queries"
+	"github.com/uptrace/bun"
+)
+
+// Interval between checks of the sessions of open websocket connections
+const sessionWatchInterval = 5 * time.Second
+
+// sessionWatcher ends websocket connections whose session is revoked or expired after the connection is authenticated.
+// Sessions of all connections are checked together by one query on every interval
+type sessionWatcher struct {
+	serverDB *bun.DB
+
+	mu          sync.Mutex
+	nextID      uint64
+	connections map[string]map[uint64]context.CancelFunc
+}
+
+func newSessionWatcher(serverDB *bun.DB) *sessionWatcher {
+	w := &sessionWatcher{
+		serverDB:    serverDB,
+		connections: make(map[string]map[uint64]context.CancelFunc),
+	}
+	go w.run()
+	return w
+}
+
+// Watch returns a context of the connection that is cancelled when the session is no longer active
+func (w *sessionWatcher) Watch(ctx context.Context, sessionID string) context.Context {
+	ctx, cancel := context.WithCancel(ctx)
+
+	w.mu.Lock()
+	id := w.nextID
+	w.nextID++
+	if w.connections[sessionID] == nil {
+		w.connections[sessionID] = make(map[uint64]context.CancelFunc)
+	}
+	w.connections[sessionID][id] = cancel
+	w.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.connections[sessionID], id)
+		if len(w.connections[sessionID]) == 0 {
+			delete(w.connections, sessionID)
+		}
+	}()
+
+	return ctx
+}
+
+func (w *sessionWatcher) run() {
+	ticker := time.NewTicker(sessionWatchInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		w.mu.Lock()
+		sessionIDs := make([]string, 0, len(w.connections))
+		for sessionID := range w.connections {
+			sessionIDs = append(sessionIDs, sessionID)
+		}
+		w.mu.Unlock()
+		if len(sessionIDs) == 0 {
+			continue
+		}
+
+		ctx := context.Background()
+		sessions, err := queries.NewSessionQuery(ctx, w.serverDB).QuerySessionsByIDs(sessionIDs)
+		if err != nil {
+			logging.GetLogger(ctx).Errorf("failed to query sessions of websocket connections: %v", err)
+			continue
+		}
+
+		activeSessionIDs := make(map[string]struct{}, len(sessions))
+		for _, session := range sessions {
+			if session.IsActive() {
+				activeSessionIDs[session.ID] = struct{}{}
+			}
+		}
+
+		w.mu.Lock()
+		for _, sessionID := range sessionIDs {
+			if _, ok := activeSessionIDs[sessionID]; ok {
+				continue
+			}
+			for _, cancel := range w.connections[sessionID] {
+				cancel()
+			}
+		}
+		w.mu.Unlock()
+	}
+}
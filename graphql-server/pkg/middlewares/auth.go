@@ -6,7 +6,6 @@ import (
 	pkgContext "github.com/oursky/likedao/pkg/context"
 	"github.com/oursky/likedao/pkg/handlers"
 	"github.com/oursky/likedao/pkg/logging"
-	"github.com/oursky/likedao/pkg/models"
 )
 
 type AuthMagicDebugHeader struct {
@@ -28,23 +27,17 @@ func Authentication(
 			}
 		}
 
-		sessionToken, err := handlers.GetSignedCookie(c, handlers.SessionCookieName)
+		session, err := handlers.GetSessionFromCookie(c)
 		if err != nil {
-			logger.Debugf("failed to get and verify signed cookie: %s", err)
+			logger.Debugf("failed to get session: %s", err)
 			return
 		}
 
-		sessionTokenData := new(models.ExpirableValue)
-		if err := sessionTokenData.ParseString(sessionToken); err != nil {
-			logger.Debugf("failed to parse session token: %s", err)
-			return
-		}
-
-		if sessionTokenData.IsExpired() {
-			return
+		if err := pkgContext.GetMutatorsFromCtx(c.Request.Context()).Session.TouchSession(session.ID); err != nil {
+			logger.Warnf("failed to update session last seen time: %s", err)
 		}
 
-		ctx := pkgContext.NewRequestContextWithAuthedUser(c.Request.Context(), sessionTokenData.Value)
+		ctx := pkgContext.NewRequestContextWithAuthedSession(c.Request.Context(), session.ID, session.Address)
 		c.Request = c.Request.WithContext(ctx)
 	}
 }
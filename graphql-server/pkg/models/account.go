@@ -0,0 +1,83 @@
+package models
+
+import (
+	"time"
+
+	bdjuno "github.com/forbole/bdjuno/database/types"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/extra/bunbig"
+)
+
+type Account struct {
+	bun.BaseModel `bun:"table:account"`
+
+	Address string `bun:"column:address,pk"`
+
+	Balance              *AccountBalance       `bun:"rel:has-one,join:address=address"`
+	Delegations          []Delegation          `bun:"rel:has-many,join:address=delegator_address"`
+	UnbondingDelegations []UnbondingDelegation `bun:"rel:has-many,join:address=delegator_address"`
+	Redelegations        []Redelegation        `bun:"rel:has-many,join:address=delegator_address"`
+	DelegationRewards    []DelegationReward    `bun:"rel:has-many,join:address=delegator_address"`
+}
+
+func (a Account) IsNode() {}
+func (a Account) NodeID() NodeID {
+	return GetNodeID(a)
+}
+
+// AccountStake is the sum of the current delegations of an address
+type AccountStake struct {
+	DelegatorAddress string     `bun:"delegator_address"`
+	Stake            bunbig.Int `bun:"stake"`
+}
+
+type AccountBalance struct {
+	bun.BaseModel `bun:"table:account_balance"`
+
+	Address string            `bun:"column:address,pk"`
+	Coins   bdjuno.DbDecCoins `bun:"column:coins,notnull"`
+	Height  int64             `bun:"column:height,notnull"`
+}
+
+type Delegation struct {
+	bun.BaseModel `bun:"table:delegation"`
+
+	ID               int              `bun:"column:id,pk"`
+	ValidatorAddress string           `bun:"column:validator_address,notnull"`
+	DelegatorAddress string           `bun:"column:delegator_address,notnull"`
+	Amount           bdjuno.DbDecCoin `bun:"column:amount,notnull"`
+	Height           int64            `bun:"column:height,notnull"`
+
+	Reward *DelegationReward `bun:"rel:has-one,join:validator_address=validator_address,join:delegator_address=delegator_address"`
+}
+
+type UnbondingDelegation struct {
+	bun.BaseModel `bun:"table:unbonding_delegation"`
+
+	ValidatorAddress string           `bun:"column:validator_address,notnull"`
+	DelegatorAddress string           `bun:"column:delegator_address,notnull"`
+	Amount           bdjuno.DbDecCoin `bun:"column:amount,notnull"`
+	CompletionTime   time.Time        `bun:"column:completion_timestamp,notnull"`
+	Height           int64            `bun:"column:height,notnull"`
+}
+
+type Redelegation struct {
+	bun.BaseModel `bun:"table:redelegation"`
+
+	DelegatorAddress    string           `bun:"column:delegator_address,notnull"`
+	SrcValidatorAddress string           `bun:"column:src_validator_address,notnull"`
+	DstValidatorAddress string           `bun:"column:dst_validator_address,notnull"`
+	Amount              bdjuno.DbDecCoin `bun:"column:amount,notnull"`
+	CompletionTime      time.Time        `bun:"column:completion_time,notnull"`
+	Height              int64            `bun:"column:height,notnull"`
+}
+
+type DelegationReward struct {
+	bun.BaseModel `bun:"table:delegation_reward"`
+
+	ValidatorAddress string            `bun:"column:validator_address,notnull"`
+	DelegatorAddress string            `bun:"column:delegator_address,notnull"`
+	WithdrawAddress  string            `bun:"column:withdraw_address,notnull"`
+	Amount           bdjuno.DbDecCoins `bun:"column:amount,notnull"`
+	Height           int64             `bun:"column:height,notnull"`
+}
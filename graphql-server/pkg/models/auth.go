@@ -48,9 +48,14 @@ func (m *ExpirableValue) IsExpired() bool {
 	return m.ExpiryTime.Before(time.Now())
 }
 
+type Coin struct {
+	Amount string `json:"amount"`
+	Denom  string `json:"denom"`
+}
+
 type Fee struct {
-	Amount []string `json:"amount"`
-	Gas    string   `json:"gas"`
+	Amount []Coin `json:"amount"`
+	Gas    string `json:"gas"`
 }
 
 type MessageSignData struct {
@@ -95,14 +100,25 @@ type SignDoc struct {
 	Messages      []Message `json:"msgs"`
 	Sequence      string    `json:"sequence"`
 }
+
+// DirectSignDoc is a protobuf SignDoc in the JSON representation used by cosmjs
+type DirectSignDoc struct {
+	BodyBytes     string `json:"bodyBytes"`     // Base64
+	AuthInfoBytes string `json:"authInfoBytes"` // Base64
+	ChainID       string `json:"chainId"`
+	AccountNumber string `json:"accountNumber"`
+}
+
 type Signature struct {
 	PubKey    PubKey `json:"pub_key"`
 	Signature string `json:"signature"`
 }
 
+// AuthenticationRequestData carries exactly one of an amino (ADR-036) or a direct sign doc
 type AuthenticationRequestData struct {
-	SignDoc   SignDoc   `json:"sign_doc"`
-	Signature Signature `json:"signature"`
+	SignDoc       *SignDoc       `json:"sign_doc"`
+	DirectSignDoc *DirectSignDoc `json:"direct_sign_doc"`
+	Signature     Signature      `json:"signature"`
 }
 
 type AuthenticationMessage struct {
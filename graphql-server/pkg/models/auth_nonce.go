@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// AuthNonce is a consumed sign-in nonce, kept until it expires so that it cannot be consumed again.
+// Nonces are only issued in signed cookies, a nonce is not recorded until it is consumed
+type AuthNonce struct {
+	bun.BaseModel `bun:"table:auth_nonce"`
+
+	Base
+
+	Nonce     string    `bun:"nonce,notnull"`
+	ExpiresAt time.Time `bun:"expires_at,notnull"`
+}
@@ -6,6 +6,9 @@ import (
 	"github.com/uptrace/bun"
 )
 
+// Maximum number of blocks of a page
+const MaxBlocksPageSize = 100
+
 type Block struct {
 	bun.BaseModel `bun:"table:block"`
 
@@ -22,3 +25,6 @@ func (b Block) IsNode() {}
 func (b Block) NodeID() NodeID {
 	return GetNodeID(b)
 }
+
+type BlockConnection = Connection[Block]
+type BlockEdge = Edge[Block]
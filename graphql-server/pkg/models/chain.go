@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"github.com/uptrace/bun"
 )
 
@@ -10,3 +12,63 @@ type AverageBlockTime struct {
 	AverageTime float64 `bun:"average_time,notnull"`
 	Height      int     `bun:"height,notnull"`
 }
+
+type StakingModuleParams struct {
+	UnbondingTime     time.Duration `json:"unbonding_time"`
+	MaxValidators     int           `json:"max_validators"`
+	MaxEntries        int           `json:"max_entries"`
+	HistoricalEntries int           `json:"historical_entries"`
+	BondDenom         string        `json:"bond_denom"`
+}
+
+type StakingParams struct {
+	bun.BaseModel `bun:"table:staking_params"`
+
+	Params StakingModuleParams `bun:"column:params,type:jsonb"`
+	Height int64               `bun:"column:height,notnull"`
+}
+
+type SlashingModuleParams struct {
+	SignedBlocksWindow      int64         `json:"signed_blocks_window"`
+	MinSignedPerWindow      string        `json:"min_signed_per_window"`
+	DowntimeJailDuration    time.Duration `json:"downtime_jail_duration"`
+	SlashFractionDoubleSign string        `json:"slash_fraction_double_sign"`
+	SlashFractionDowntime   string        `json:"slash_fraction_downtime"`
+}
+
+type SlashingParams struct {
+	bun.BaseModel `bun:"table:slashing_params"`
+
+	Params SlashingModuleParams `bun:"column:params,type:jsonb"`
+	Height int64                `bun:"column:height,notnull"`
+}
+
+type MintModuleParams struct {
+	MintDenom           string `json:"mint_denom"`
+	InflationRateChange string `json:"inflation_rate_change"`
+	InflationMax        string `json:"inflation_max"`
+	InflationMin        string `json:"inflation_min"`
+	GoalBonded          string `json:"goal_bonded"`
+	BlocksPerYear       uint64 `json:"blocks_per_year"`
+}
+
+type MintParams struct {
+	bun.BaseModel `bun:"table:mint_params"`
+
+	Params MintModuleParams `bun:"column:params,type:jsonb"`
+	Height int64            `bun:"column:height,notnull"`
+}
+
+type DistributionModuleParams struct {
+	CommunityTax        string `json:"community_tax"`
+	BaseProposerReward  string `json:"base_proposer_reward"`
+	BonusProposerReward string `json:"bonus_proposer_reward"`
+	WithdrawAddrEnabled bool   `json:"withdraw_addr_enabled"`
+}
+
+type DistributionParams struct {
+	bun.BaseModel `bun:"table:distribution_params"`
+
+	Params DistributionModuleParams `bun:"column:params,type:jsonb"`
+	Height int64                    `bun:"column:height,notnull"`
+}
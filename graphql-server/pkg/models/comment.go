@@ -0,0 +1,52 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/uptrace/bun"
+)
+
+// Maximum length of the body of a comment in characters
+const MaxCommentBodyLength = 10000
+
+// Comment is an off-chain comment on a proposal, a reply has the comment it replies to as parent.
+// A deleted comment is kept to preserve its replies
+type Comment struct {
+	bun.BaseModel `bun:"table:comment"`
+
+	Base
+
+	ProposalID int        `bun:"proposal_id,notnull"`
+	ParentID   *string    `bun:"parent_id"`
+	Author     string     `bun:"author,notnull"`
+	Body       string     `bun:"body,notnull"`
+	EditedAt   *time.Time `bun:"edited_at"`
+	DeletedAt  *time.Time `bun:"deleted_at"`
+}
+
+func (c Comment) IsNode() {}
+func (c Comment) NodeID() NodeID {
+	return GetNodeID(c)
+}
+
+func (c Comment) IsDeleted() bool {
+	return c.DeletedAt != nil
+}
+
+// NormalizeCommentBody trims surrounding whitespaces of body and checks it is not empty nor too long
+func NormalizeCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", fmt.Errorf("body must not be empty")
+	}
+	if utf8.RuneCountInString(body) > MaxCommentBodyLength {
+		return "", fmt.Errorf("body must not be longer than %d characters", MaxCommentBodyLength)
+	}
+	return body, nil
+}
+
+type CommentConnection = Connection[Comment]
+type CommentEdge = Edge[Comment]
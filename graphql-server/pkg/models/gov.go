@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	bdjuno "github.com/forbole/bdjuno/database/types"
+	"github.com/uptrace/bun"
+)
+
+type GovDepositParams struct {
+	MinDeposit       []bdjuno.DbDecCoin `json:"min_deposit"`
+	MaxDepositPeriod time.Duration      `json:"max_deposit_period"`
+}
+
+type GovVotingParams struct {
+	VotingPeriod time.Duration `json:"voting_period"`
+}
+
+type GovTallyParams struct {
+	Quorum        string `json:"quorum"`
+	Threshold     string `json:"threshold"`
+	VetoThreshold string `json:"veto_threshold"`
+}
+
+type GovParams struct {
+	bun.BaseModel `bun:"table:gov_params"`
+
+	DepositParams GovDepositParams `bun:"column:deposit_params,type:jsonb"`
+	VotingParams  GovVotingParams  `bun:"column:voting_params,type:jsonb"`
+	TallyParams   GovTallyParams   `bun:"column:tally_params,type:jsonb"`
+	Height        int64            `bun:"column:height,notnull"`
+}
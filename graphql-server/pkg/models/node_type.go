@@ -22,6 +22,16 @@ func GetNodeID(obj interface{}) NodeID {
 		return NodeID{EntityType: "proposalDeposit", ID: v.ID().String()}
 	case Validator:
 		return NodeID{EntityType: "validator", ID: v.ConsensusAddress}
+	case Session:
+		return NodeID{EntityType: "session", ID: v.ID}
+	case Transaction:
+		return NodeID{EntityType: "transaction", ID: v.Hash}
+	case Account:
+		return NodeID{EntityType: "account", ID: v.Address}
+	case Comment:
+		return NodeID{EntityType: "comment", ID: v.ID}
+	case Post:
+		return NodeID{EntityType: "post", ID: v.ID}
 	default:
 		panic(fmt.Sprintf(
 			`unknown entity type "%s"`,
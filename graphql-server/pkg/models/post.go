@@ -0,0 +1,128 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+var iscnIDRegex = regexp.MustCompile(`^(iscn://[-_.:=+,a-zA-Z0-9]+/[-_.:=+,a-zA-Z0-9]+)(?:/([0-9]+))?$`)
+
+// ParseISCNID returns the ISCN ID without version and the version, which is 0 if the ID is not versioned
+func ParseISCNID(iscnID string) (string, int, error) {
+	matches := iscnIDRegex.FindStringSubmatch(iscnID)
+	if matches == nil {
+		return "", 0, fmt.Errorf("invalid ISCN ID: %s", iscnID)
+	}
+	if matches[2] == "" {
+		return matches[1], 0, nil
+	}
+
+	version, err := strconv.Atoi(matches[2])
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid ISCN ID version: %s", iscnID)
+	}
+	return matches[1], version, nil
+}
+
+// Post is the latest version of an ISCN record mirrored from chain.
+// ISCN IDs are not valid node IDs, so a post is identified by its own ID and reactions target its ISCN ID
+type Post struct {
+	bun.BaseModel `bun:"table:post"`
+
+	Base
+
+	ISCNID              string          `bun:"iscn_id,notnull"`
+	Version             int             `bun:"version,notnull"`
+	Owner               string          `bun:"owner,notnull"`
+	Title               *string         `bun:"title"`
+	Description         *string         `bun:"description"`
+	URL                 *string         `bun:"url"`
+	ContentFingerprints []string        `bun:"content_fingerprints,array,notnull"`
+	ProposalID          *int            `bun:"proposal_id"`
+	RecordTimestamp     time.Time       `bun:"record_timestamp,notnull"`
+	Record              json.RawMessage `bun:"record,type:jsonb,notnull"`
+}
+
+func (p Post) IsNode() {}
+func (p Post) NodeID() NodeID {
+	return GetNodeID(p)
+}
+
+type PostConnection = Connection[Post]
+type PostEdge = Edge[Post]
+
+// ISCNRecord is the data of a version of an ISCN record, see https://github.com/likecoin/iscn-specs
+type ISCNRecord struct {
+	ID                  string   `json:"@id"`
+	RecordVersion       int      `json:"recordVersion"`
+	RecordTimestamp     string   `json:"recordTimestamp"`
+	ContentFingerprints []string `json:"contentFingerprints"`
+	ContentMetadata     struct {
+		Name        *string `json:"name"`
+		Description *string `json:"description"`
+		URL         *string `json:"url"`
+		// ID of the proposal the post is about, it is a number or a string of number
+		ProposalID *json.Number `json:"proposalId"`
+	} `json:"contentMetadata"`
+}
+
+// ISCNRecordsResponse is the response of querying ISCN records by ID or owner from the chain REST API
+type ISCNRecordsResponse struct {
+	Owner   string `json:"owner"`
+	Records []struct {
+		Data json.RawMessage `json:"data"`
+	} `json:"records"`
+}
+
+// NewPostFromISCNRecord creates a post from the JSON data of an ISCN record owned by owner
+func NewPostFromISCNRecord(owner string, data json.RawMessage) (*Post, error) {
+	var record ISCNRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("failed to decode ISCN record: %w", err)
+	}
+
+	iscnID, version, err := ParseISCNID(record.ID)
+	if err != nil {
+		return nil, err
+	}
+	if version == 0 {
+		version = record.RecordVersion
+	}
+
+	recordTimestamp, err := time.Parse(time.RFC3339, record.RecordTimestamp)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timestamp of ISCN record %s: %w", record.ID, err)
+	}
+
+	var proposalID *int
+	if record.ContentMetadata.ProposalID != nil {
+		id, err := strconv.Atoi(record.ContentMetadata.ProposalID.String())
+		if err != nil {
+			return nil, fmt.Errorf("invalid proposal ID of ISCN record %s: %w", record.ID, err)
+		}
+		proposalID = &id
+	}
+
+	contentFingerprints := record.ContentFingerprints
+	if contentFingerprints == nil {
+		contentFingerprints = []string{}
+	}
+
+	return &Post{
+		ISCNID:              iscnID,
+		Version:             version,
+		Owner:               owner,
+		Title:               record.ContentMetadata.Name,
+		Description:         record.ContentMetadata.Description,
+		URL:                 record.ContentMetadata.URL,
+		ContentFingerprints: contentFingerprints,
+		ProposalID:          proposalID,
+		RecordTimestamp:     recordTimestamp.UTC(),
+		Record:              data,
+	}, nil
+}
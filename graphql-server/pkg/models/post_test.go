@@ -0,0 +1,148 @@
+package models_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/oursky/likedao/pkg/models"
+)
+
+func intPtr(value int) *int {
+	return &value
+}
+
+func Test_ParseISCNID(t *testing.T) {
+	testCases := []struct {
+		name    string
+		iscnID  string
+		id      string
+		version int
+	}{
+		{"Versioned", "iscn://likecoin-chain/abc_DEF-123/2", "iscn://likecoin-chain/abc_DEF-123", 2},
+		{"Unversioned", "iscn://likecoin-chain/abc_DEF-123", "iscn://likecoin-chain/abc_DEF-123", 0},
+		{"Version 0", "iscn://likecoin-chain/abc/0", "iscn://likecoin-chain/abc", 0},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			id, version, err := models.ParseISCNID(testCase.iscnID)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if id != testCase.id {
+				t.Errorf("expected id %s, got %s", testCase.id, id)
+			}
+			if version != testCase.version {
+				t.Errorf("expected version %d, got %d", testCase.version, version)
+			}
+		})
+	}
+}
+
+func Test_ParseISCNIDFail(t *testing.T) {
+	testCases := []struct {
+		name   string
+		iscnID string
+	}{
+		{"Empty", ""},
+		{"Missing scheme", "likecoin-chain/abc/1"},
+		{"Missing record ID", "iscn://likecoin-chain"},
+		{"Non numeric version", "iscn://likecoin-chain/abc/v1"},
+		{"Trailing slash", "iscn://likecoin-chain/abc/"},
+		{"Version overflow", "iscn://likecoin-chain/abc/99999999999999999999"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if _, _, err := models.ParseISCNID(testCase.iscnID); err == nil {
+				t.Errorf("expected error for %s, got no error", testCase.iscnID)
+			}
+		})
+	}
+}
+
+func Test_NewPostFromISCNRecord(t *testing.T) {
+	testCases := []struct {
+		name       string
+		data       string
+		version    int
+		proposalID *int
+	}{
+		{
+			name:       "Versioned ID",
+			data:       `{"@id":"iscn://likecoin-chain/abc/3","recordVersion":1,"recordTimestamp":"2022-06-01T12:00:00+08:00","contentFingerprints":["hash://sha256/1"],"contentMetadata":{"name":"Title","proposalId":12}}`,
+			version:    3,
+			proposalID: intPtr(12),
+		},
+		{
+			name:       "Unversioned ID uses record version",
+			data:       `{"@id":"iscn://likecoin-chain/abc","recordVersion":2,"recordTimestamp":"2022-06-01T12:00:00+08:00","contentFingerprints":["hash://sha256/1"],"contentMetadata":{"name":"Title","proposalId":12}}`,
+			version:    2,
+			proposalID: intPtr(12),
+		},
+		{
+			name:       "String proposal ID",
+			data:       `{"@id":"iscn://likecoin-chain/abc/1","recordTimestamp":"2022-06-01T12:00:00+08:00","contentMetadata":{"proposalId":"12"}}`,
+			version:    1,
+			proposalID: intPtr(12),
+		},
+		{
+			name:       "No proposal ID",
+			data:       `{"@id":"iscn://likecoin-chain/abc/1","recordTimestamp":"2022-06-01T12:00:00+08:00","contentMetadata":{}}`,
+			version:    1,
+			proposalID: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			post, err := models.NewPostFromISCNRecord("like1owner", json.RawMessage(testCase.data))
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if post.ISCNID != "iscn://likecoin-chain/abc" {
+				t.Errorf("expected ISCN ID without version, got %s", post.ISCNID)
+			}
+			if post.Version != testCase.version {
+				t.Errorf("expected version %d, got %d", testCase.version, post.Version)
+			}
+			if post.Owner != "like1owner" {
+				t.Errorf("expected owner like1owner, got %s", post.Owner)
+			}
+			if (post.ProposalID == nil) != (testCase.proposalID == nil) ||
+				(post.ProposalID != nil && *post.ProposalID != *testCase.proposalID) {
+				t.Errorf("expected proposal ID %v, got %v", testCase.proposalID, post.ProposalID)
+			}
+			if expected := time.Date(2022, 6, 1, 4, 0, 0, 0, time.UTC); !post.RecordTimestamp.Equal(expected) || post.RecordTimestamp.Location() != time.UTC {
+				t.Errorf("expected record timestamp %v, got %v", expected, post.RecordTimestamp)
+			}
+			if post.ContentFingerprints == nil {
+				t.Errorf("expected content fingerprints not to be nil")
+			}
+		})
+	}
+}
+
+func Test_NewPostFromISCNRecordFail(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+	}{
+		{"Not JSON", `not json`},
+		{"Invalid ID", `{"@id":"abc","recordTimestamp":"2022-06-01T12:00:00Z","contentMetadata":{}}`},
+		{"Bad timestamp", `{"@id":"iscn://likecoin-chain/abc/1","recordTimestamp":"2022-06-01 12:00:00","contentMetadata":{}}`},
+		{"Missing timestamp", `{"@id":"iscn://likecoin-chain/abc/1","contentMetadata":{}}`},
+		{"Non numeric proposal ID", `{"@id":"iscn://likecoin-chain/abc/1","recordTimestamp":"2022-06-01T12:00:00Z","contentMetadata":{"proposalId":"abc"}}`},
+		{"Fractional proposal ID", `{"@id":"iscn://likecoin-chain/abc/1","recordTimestamp":"2022-06-01T12:00:00Z","contentMetadata":{"proposalId":1.5}}`},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if _, err := models.NewPostFromISCNRecord("like1owner", json.RawMessage(testCase.data)); err == nil {
+				t.Errorf("expected error, got no error")
+			}
+		})
+	}
+}
@@ -1,12 +1,16 @@
 package models
 
 import (
+	"encoding/json"
 	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 	"time"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	bdjuno "github.com/forbole/bdjuno/database/types"
+	"github.com/pkg/errors"
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/extra/bunbig"
 )
@@ -86,18 +90,22 @@ func (e *ProposalVoteOption) UnmarshalGQL(v interface{}) error {
 type Proposal struct {
 	bun.BaseModel `bun:"table:proposal"`
 
-	// Skipping content field because already parsed by bdjuno
-	ID              int            `bun:"column:id,pk"`
-	Title           string         `bun:"column:title,notnull"`
-	Description     string         `bun:"column:description,notnull"`
-	ProposalRoute   string         `bun:"column:proposal_route,notnull"`
-	ProposalType    string         `bun:"column:proposal_type,notnull"`
-	SubmitTime      time.Time      `bun:"column:submit_time,notnull"`
-	DepositEndTime  time.Time      `bun:"column:deposit_end_time"`
-	VotingStartTime time.Time      `bun:"column:voting_start_time"`
-	VotingEndTime   time.Time      `bun:"column:voting_end_time"`
-	ProposerAddress string         `bun:"column:proposer_address,notnull"`
-	Status          ProposalStatus `bun:"column:status,notnull"`
+	ID              int             `bun:"column:id,pk"`
+	Title           string          `bun:"column:title,notnull"`
+	Description     string          `bun:"column:description,notnull"`
+	RawContent      json.RawMessage `bun:"column:content,type:jsonb,notnull"`
+	ProposalRoute   string          `bun:"column:proposal_route,notnull"`
+	ProposalType    string          `bun:"column:proposal_type,notnull"`
+	SubmitTime      time.Time       `bun:"column:submit_time,notnull"`
+	DepositEndTime  time.Time       `bun:"column:deposit_end_time"`
+	VotingStartTime time.Time       `bun:"column:voting_start_time"`
+	VotingEndTime   time.Time       `bun:"column:voting_end_time"`
+	ProposerAddress string          `bun:"column:proposer_address,notnull"`
+	Status          ProposalStatus  `bun:"column:status,notnull"`
+
+	// Relevance to the search term, only selected when searching
+	SearchRank       string `bun:"search_rank,scanonly"`
+	SearchTitleMatch bool   `bun:"search_title_match,scanonly"`
 }
 
 func (p Proposal) IsNode() {}
@@ -105,9 +113,69 @@ func (p Proposal) NodeID() NodeID {
 	return GetNodeID(p)
 }
 
+// Content decodes the proposal content by the proposal type, title and description are
+// already parsed by bdjuno so types without other fields have no content
+func (p Proposal) Content() (ProposalContent, error) {
+	var content ProposalContent
+	switch ProposalType(p.ProposalType) {
+	case ProposalTypeParameterChange:
+		content = &ParameterChangeProposalContent{}
+	case ProposalTypeCommunityPoolSpend:
+		content = &CommunityPoolSpendProposalContent{}
+	case ProposalTypeSoftwareUpgrade:
+		content = &SoftwareUpgradeProposalContent{}
+	default:
+		return nil, nil
+	}
+
+	if err := json.Unmarshal(p.RawContent, content); err != nil {
+		return nil, fmt.Errorf("invalid %s proposal content: %w", p.ProposalType, err)
+	}
+	return content, nil
+}
+
 type ProposalConnection = Connection[Proposal]
 type ProposalEdge = Edge[Proposal]
 
+// Proposal contents are stored in proto JSON, where 64-bit integers are encoded as strings
+
+type ParameterChange struct {
+	Subspace string `json:"subspace"`
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+}
+
+// ParamKey identifies a param of the params module
+type ParamKey struct {
+	Subspace string
+	Key      string
+}
+
+type ParameterChangeProposalContent struct {
+	Changes []ParameterChange `json:"changes"`
+}
+
+func (c ParameterChangeProposalContent) IsProposalContent() {}
+
+type CommunityPoolSpendProposalContent struct {
+	Recipient string             `json:"recipient"`
+	Amount    []bdjuno.DbDecCoin `json:"amount"`
+}
+
+func (c CommunityPoolSpendProposalContent) IsProposalContent() {}
+
+type SoftwareUpgradePlan struct {
+	Name   string `json:"name"`
+	Height int    `json:"height,string"`
+	Info   string `json:"info"`
+}
+
+type SoftwareUpgradeProposalContent struct {
+	Plan SoftwareUpgradePlan `json:"plan"`
+}
+
+func (c SoftwareUpgradeProposalContent) IsProposalContent() {}
+
 type ProposalDepositKey struct {
 	ProposalID int
 	Address    string
@@ -172,17 +240,34 @@ type ProposalVoteKey struct {
 	ProposalID int
 	Address    string
 }
+
+type ProposalVoteWeightedOption struct {
+	Option ProposalVoteOption `json:"option"`
+	Weight string             `json:"weight"`
+}
+
+// ProposalVote is a vote of a voter on a proposal, the proposal_vote table stores a row for each
+// option of a weighted vote so votes have to be queried with the grouped table of the proposal query
 type ProposalVote struct {
 	bun.BaseModel `bun:"table:proposal_vote"`
 
-	ProposalID   int                `bun:"column:proposal_id,pk"`
-	VoterAddress string             `bun:"column:voter_address,notnull"`
-	Option       ProposalVoteOption `bun:"column:option,notnull"`
-	Height       int64              `bun:"column:height,notnull"`
+	ProposalID   int    `bun:"column:proposal_id,pk"`
+	VoterAddress string `bun:"column:voter_address,notnull"`
+	// The option with the largest weight
+	Option  ProposalVoteOption           `bun:"column:option,notnull"`
+	Options []ProposalVoteWeightedOption `bun:"column:options,type:jsonb"`
+	Height  int64                        `bun:"column:height,notnull"`
+	// Only selected when querying votes of a proposal
+	VotingPower *bunbig.Int `bun:"voting_power,scanonly"`
 
 	ValidatorInfo *ValidatorInfo `bun:"rel:has-one,join:voter_address=self_delegate_address"`
 }
 
+// IsWeighted returns whether the voter split the vote across multiple options
+func (p ProposalVote) IsWeighted() bool {
+	return len(p.Options) > 1
+}
+
 func (p ProposalVote) ID() ProposalVoteID {
 	return ProposalVoteID{
 		ProposalID: p.ProposalID,
@@ -240,7 +325,263 @@ type ProposalTallyResult struct {
 	Height     int64      `bun:"column:height,notnull"`
 }
 
+func (t ProposalTallyResult) Total() *big.Int {
+	total := new(big.Int).Add(t.Yes.ToMathBig(), t.No.ToMathBig())
+	total.Add(total, t.Abstain.ToMathBig())
+	return total.Add(total, t.NoWithVeto.ToMathBig())
+}
+
+// Equals compares the vote counts of two tally results, regardless of the heights they are recorded
+func (t ProposalTallyResult) Equals(other ProposalTallyResult) bool {
+	return t.Yes.Cmp(&other.Yes).Eq() &&
+		t.No.Cmp(&other.No).Eq() &&
+		t.Abstain.Cmp(&other.Abstain).Eq() &&
+		t.NoWithVeto.Cmp(&other.NoWithVeto).Eq()
+}
+
+type ProposalVotingPowerSum struct {
+	ProposalID  int                `bun:"proposal_id"`
+	Option      ProposalVoteOption `bun:"option"`
+	IsValidator bool               `bun:"is_validator"`
+	VotingPower bunbig.Int         `bun:"voting_power"`
+}
+
+// ProposalVotingPowerBreakdown splits the voting power of votes on a proposal by whether the voter is a validator
+type ProposalVotingPowerBreakdown struct {
+	ValidatorVotes ProposalTallyResult
+	DelegatorVotes ProposalTallyResult
+}
+
+func NewProposalVotingPowerBreakdown(proposalID int) *ProposalVotingPowerBreakdown {
+	return &ProposalVotingPowerBreakdown{
+		ValidatorVotes: ProposalTallyResult{ProposalID: proposalID},
+		DelegatorVotes: ProposalTallyResult{ProposalID: proposalID},
+	}
+}
+
+func (b *ProposalVotingPowerBreakdown) Add(sum ProposalVotingPowerSum) {
+	tally := &b.DelegatorVotes
+	if sum.IsValidator {
+		tally = &b.ValidatorVotes
+	}
+
+	var count *bunbig.Int
+	switch sum.Option {
+	case ProposalVoteOptionYes:
+		count = &tally.Yes
+	case ProposalVoteOptionNo:
+		count = &tally.No
+	case ProposalVoteOptionAbstain:
+		count = &tally.Abstain
+	case ProposalVoteOptionNoWithVeto:
+		count = &tally.NoWithVeto
+	default:
+		return
+	}
+	*count = *count.Add(&sum.VotingPower)
+}
+
+// ValidatorShare returns the share of voting power of all votes from validators, nil if there is no voting power
+func (b ProposalVotingPowerBreakdown) ValidatorShare() *float64 {
+	validatorTotal := b.ValidatorVotes.Total()
+	total := new(big.Int).Add(validatorTotal, b.DelegatorVotes.Total())
+	if total.Sign() == 0 {
+		return nil
+	}
+
+	share, _ := new(big.Rat).SetFrac(validatorTotal, total).Float64()
+	return &share
+}
+
+type ProposalStakingPoolSnapshot struct {
+	bun.BaseModel `bun:"table:proposal_staking_pool_snapshot"`
+
+	ProposalID      int        `bun:"column:proposal_id,pk"`
+	BondedTokens    bunbig.Int `bun:"column:bonded_tokens,notnull"`
+	NotBondedTokens bunbig.Int `bun:"column:not_bonded_tokens,notnull"`
+	Height          int64      `bun:"column:height,notnull"`
+}
+
 type ProposalTurnout struct {
 	ProposalID int
 	Turnout    float64
 }
+
+// NewProposalOutcome projects the outcome of a proposal as if voting ends with the tally,
+// following the tally logic of the gov module
+func NewProposalOutcome(tally ProposalTallyResult, bondedTokens bunbig.Int, params GovTallyParams) (*ProposalOutcome, error) {
+	quorum, err := sdk.NewDecFromStr(params.Quorum)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid quorum")
+	}
+	threshold, err := sdk.NewDecFromStr(params.Threshold)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid threshold")
+	}
+	vetoThreshold, err := sdk.NewDecFromStr(params.VetoThreshold)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid veto threshold")
+	}
+
+	yes := sdk.NewDecFromBigInt(tally.Yes.ToMathBig())
+	abstain := sdk.NewDecFromBigInt(tally.Abstain.ToMathBig())
+	noWithVeto := sdk.NewDecFromBigInt(tally.NoWithVeto.ToMathBig())
+	total := yes.Add(sdk.NewDecFromBigInt(tally.No.ToMathBig())).Add(abstain).Add(noWithVeto)
+	nonAbstain := total.Sub(abstain)
+	bonded := sdk.NewDecFromBigInt(bondedTokens.ToMathBig())
+
+	quorumVotes := bonded.Mul(quorum)
+	quorumReached := bonded.IsPositive() && total.GTE(quorumVotes)
+	vetoTriggered := total.IsPositive() && noWithVeto.Quo(total).GT(vetoThreshold)
+	thresholdMet := nonAbstain.IsPositive() && yes.Quo(nonAbstain).GT(threshold)
+
+	var projectedResult ProposalProjectedResult
+	switch {
+	case !quorumReached:
+		projectedResult = ProposalProjectedResultQuorumNotReached
+	case vetoTriggered:
+		projectedResult = ProposalProjectedResultRejectedWithVeto
+	case thresholdMet:
+		projectedResult = ProposalProjectedResultPassed
+	default:
+		projectedResult = ProposalProjectedResultRejected
+	}
+
+	// Quorum cannot be reached without bonded tokens, in which case no number of votes is needed
+	votesNeededForQuorum := sdk.ZeroInt()
+	if !quorumReached && bonded.IsPositive() {
+		votesNeededForQuorum = quorumVotes.Sub(total).Ceil().TruncateInt()
+	}
+
+	outcome := &ProposalOutcome{
+		Quorum:                  decToFloat(quorum),
+		Threshold:               decToFloat(threshold),
+		VetoThreshold:           decToFloat(vetoThreshold),
+		QuorumReached:           quorumReached,
+		ThresholdMet:            thresholdMet,
+		VetoTriggered:           vetoTriggered,
+		ProjectedResult:         projectedResult,
+		VotesNeededForQuorum:    BigInt(votesNeededForQuorum.String()),
+		VotesNeededForThreshold: votesNeededToExceed(yes, nonAbstain, threshold),
+		VotesNeededForVeto:      votesNeededToExceed(noWithVeto, total, vetoThreshold),
+	}
+	return outcome, nil
+}
+
+// votesNeededToExceed returns the least number of votes x so that (votes + x) / (total + x) > ratio,
+// nil if the ratio cannot be exceeded
+func votesNeededToExceed(votes sdk.Dec, total sdk.Dec, ratio sdk.Dec) *BigInt {
+	if total.IsPositive() && votes.Quo(total).GT(ratio) {
+		zero := NewBigInt(0)
+		return &zero
+	}
+
+	remainingRatio := sdk.OneDec().Sub(ratio)
+	if !remainingRatio.IsPositive() {
+		return nil
+	}
+
+	needed := BigInt(ratio.Mul(total).Sub(votes).Quo(remainingRatio).TruncateInt().AddRaw(1).String())
+	return &needed
+}
+
+func decToFloat(d sdk.Dec) float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// ProposalDelegationVote is how a delegation of a delegator votes on a proposal. The vote of the delegator
+// overrides the vote of the validator on the delegation, and only delegations to bonded validators are tallied
+type ProposalDelegationVote struct {
+	ValidatorAddress string
+	Amount           bdjuno.DbDecCoin
+	Tallied          bool
+	ValidatorVote    *ProposalVote
+	DelegatorVote    *ProposalVote
+}
+
+func (v ProposalDelegationVote) Overridden() bool {
+	return v.DelegatorVote != nil
+}
+
+// EffectiveOptions returns the options the delegation votes, empty if neither the delegator nor the validator voted
+func (v ProposalDelegationVote) EffectiveOptions() []ProposalVoteWeightedOption {
+	if v.DelegatorVote != nil {
+		return v.DelegatorVote.Options
+	}
+	if v.ValidatorVote != nil {
+		return v.ValidatorVote.Options
+	}
+	return []ProposalVoteWeightedOption{}
+}
+
+type ProposalVoteOptionStake struct {
+	Option ProposalVoteOption
+	Amount BigInt
+}
+
+// ProposalGovernanceSummary is how the stake delegated by a delegator votes on a proposal
+type ProposalGovernanceSummary struct {
+	Vote          *ProposalVote
+	Delegations   []ProposalDelegationVote
+	StakeByOption []ProposalVoteOptionStake
+	NotVotedStake BigInt
+}
+
+// NewProposalGovernanceSummary splits the tallied stake of delegations by the weights of their effective options
+func NewProposalGovernanceSummary(vote *ProposalVote, delegations []ProposalDelegationVote) (*ProposalGovernanceSummary, error) {
+	options := []ProposalVoteOption{
+		ProposalVoteOptionYes,
+		ProposalVoteOptionNo,
+		ProposalVoteOptionAbstain,
+		ProposalVoteOptionNoWithVeto,
+	}
+	optionStakes := make(map[ProposalVoteOption]sdk.Dec, len(options))
+	for _, option := range options {
+		optionStakes[option] = sdk.ZeroDec()
+	}
+	notVotedStake := sdk.ZeroDec()
+
+	for _, delegation := range delegations {
+		if !delegation.Tallied {
+			continue
+		}
+
+		amount, err := sdk.NewDecFromStr(delegation.Amount.Amount)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid delegation amount to %s", delegation.ValidatorAddress)
+		}
+
+		effectiveOptions := delegation.EffectiveOptions()
+		if len(effectiveOptions) == 0 {
+			notVotedStake = notVotedStake.Add(amount)
+			continue
+		}
+		for _, option := range effectiveOptions {
+			weight, err := sdk.NewDecFromStr(option.Weight)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid weight of option %s", option.Option)
+			}
+			optionStake, ok := optionStakes[option.Option]
+			if !ok {
+				return nil, errors.Errorf("unknown vote option %s", option.Option)
+			}
+			optionStakes[option.Option] = optionStake.Add(amount.Mul(weight))
+		}
+	}
+
+	stakeByOption := make([]ProposalVoteOptionStake, 0, len(options))
+	for _, option := range options {
+		stakeByOption = append(stakeByOption, ProposalVoteOptionStake{
+			Option: option,
+			Amount: BigInt(optionStakes[option].TruncateInt().String()),
+		})
+	}
+
+	return &ProposalGovernanceSummary{
+		Vote:          vote,
+		Delegations:   delegations,
+		StakeByOption: stakeByOption,
+		NotVotedStake: BigInt(notVotedStake.TruncateInt().String()),
+	}, nil
+}
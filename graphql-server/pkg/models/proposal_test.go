@@ -0,0 +1,436 @@
+package models_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	bdjuno "github.com/forbole/bdjuno/database/types"
+	"github.com/oursky/likedao/pkg/models"
+	"github.com/uptrace/bun/extra/bunbig"
+)
+
+func newTallyResult(yes int64, no int64, abstain int64, noWithVeto int64) models.ProposalTallyResult {
+	return models.ProposalTallyResult{
+		Yes:        *bunbig.FromInt64(yes),
+		No:         *bunbig.FromInt64(no),
+		Abstain:    *bunbig.FromInt64(abstain),
+		NoWithVeto: *bunbig.FromInt64(noWithVeto),
+	}
+}
+
+func newWeightedVote(options ...models.ProposalVoteWeightedOption) *models.ProposalVote {
+	return &models.ProposalVote{Option: options[0].Option, Options: options}
+}
+
+func newDelegationVote(amount string, tallied bool, validatorVote *models.ProposalVote, delegatorVote *models.ProposalVote) models.ProposalDelegationVote {
+	return models.ProposalDelegationVote{
+		ValidatorAddress: "likevalcons1abc",
+		Amount:           bdjuno.DbDecCoin{Denom: "nanolike", Amount: amount},
+		Tallied:          tallied,
+		ValidatorVote:    validatorVote,
+		DelegatorVote:    delegatorVote,
+	}
+}
+
+func formatVotesNeeded(votes *models.BigInt) string {
+	if votes == nil {
+		return "nil"
+	}
+	return string(*votes)
+}
+
+func Test_NewProposalOutcome(t *testing.T) {
+	params := models.GovTallyParams{
+		Quorum:        "0.400000000000000000",
+		Threshold:     "0.500000000000000000",
+		VetoThreshold: "0.334000000000000000",
+	}
+
+	testCases := []struct {
+		name                    string
+		tally                   models.ProposalTallyResult
+		bondedTokens            int64
+		params                  models.GovTallyParams
+		quorumReached           bool
+		thresholdMet            bool
+		vetoTriggered           bool
+		projectedResult         models.ProposalProjectedResult
+		votesNeededForQuorum    string
+		votesNeededForThreshold string
+		votesNeededForVeto      string
+	}{
+		{
+			name:                    "Quorum not reached",
+			tally:                   newTallyResult(300, 0, 0, 0),
+			bondedTokens:            1000,
+			params:                  params,
+			quorumReached:           false,
+			thresholdMet:            true,
+			vetoTriggered:           false,
+			projectedResult:         models.ProposalProjectedResultQuorumNotReached,
+			votesNeededForQuorum:    "100",
+			votesNeededForThreshold: "0",
+			votesNeededForVeto:      "151",
+		},
+		{
+			name:                    "Quorum reached at exactly quorum",
+			tally:                   newTallyResult(400, 0, 0, 0),
+			bondedTokens:            1000,
+			params:                  params,
+			quorumReached:           true,
+			thresholdMet:            true,
+			vetoTriggered:           false,
+			projectedResult:         models.ProposalProjectedResultPassed,
+			votesNeededForQuorum:    "0",
+			votesNeededForThreshold: "0",
+			votesNeededForVeto:      "201",
+		},
+		{
+			name:                    "Threshold not met at exactly threshold",
+			tally:                   newTallyResult(250, 250, 0, 0),
+			bondedTokens:            1000,
+			params:                  params,
+			quorumReached:           true,
+			thresholdMet:            false,
+			vetoTriggered:           false,
+			projectedResult:         models.ProposalProjectedResultRejected,
+			votesNeededForQuorum:    "0",
+			votesNeededForThreshold: "1",
+			votesNeededForVeto:      "251",
+		},
+		{
+			name:                    "Abstain counted for quorum but not threshold",
+			tally:                   newTallyResult(200, 100, 300, 0),
+			bondedTokens:            1000,
+			params:                  params,
+			quorumReached:           true,
+			thresholdMet:            true,
+			vetoTriggered:           false,
+			projectedResult:         models.ProposalProjectedResultPassed,
+			votesNeededForQuorum:    "0",
+			votesNeededForThreshold: "0",
+			votesNeededForVeto:      "301",
+		},
+		{
+			name:                    "Veto not triggered at exactly veto threshold",
+			tally:                   newTallyResult(666, 0, 0, 334),
+			bondedTokens:            1000,
+			params:                  params,
+			quorumReached:           true,
+			thresholdMet:            true,
+			vetoTriggered:           false,
+			projectedResult:         models.ProposalProjectedResultPassed,
+			votesNeededForQuorum:    "0",
+			votesNeededForThreshold: "0",
+			votesNeededForVeto:      "1",
+		},
+		{
+			name:                    "Veto triggered over threshold met",
+			tally:                   newTallyResult(600, 0, 0, 400),
+			bondedTokens:            1000,
+			params:                  params,
+			quorumReached:           true,
+			thresholdMet:            true,
+			vetoTriggered:           true,
+			projectedResult:         models.ProposalProjectedResultRejectedWithVeto,
+			votesNeededForQuorum:    "0",
+			votesNeededForThreshold: "0",
+			votesNeededForVeto:      "0",
+		},
+		{
+			name:                    "No votes",
+			tally:                   newTallyResult(0, 0, 0, 0),
+			bondedTokens:            1000,
+			params:                  params,
+			quorumReached:           false,
+			thresholdMet:            false,
+			vetoTriggered:           false,
+			projectedResult:         models.ProposalProjectedResultQuorumNotReached,
+			votesNeededForQuorum:    "400",
+			votesNeededForThreshold: "1",
+			votesNeededForVeto:      "1",
+		},
+		{
+			name:                    "Zero bonded pool",
+			tally:                   newTallyResult(10, 0, 0, 0),
+			bondedTokens:            0,
+			params:                  params,
+			quorumReached:           false,
+			thresholdMet:            true,
+			vetoTriggered:           false,
+			projectedResult:         models.ProposalProjectedResultQuorumNotReached,
+			votesNeededForQuorum:    "0",
+			votesNeededForThreshold: "0",
+			votesNeededForVeto:      "6",
+		},
+		{
+			name:         "Threshold of 1 cannot be met",
+			tally:        newTallyResult(500, 500, 0, 0),
+			bondedTokens: 1000,
+			params: models.GovTallyParams{
+				Quorum:        params.Quorum,
+				Threshold:     "1.000000000000000000",
+				VetoThreshold: params.VetoThreshold,
+			},
+			quorumReached:           true,
+			thresholdMet:            false,
+			vetoTriggered:           false,
+			projectedResult:         models.ProposalProjectedResultRejected,
+			votesNeededForQuorum:    "0",
+			votesNeededForThreshold: "nil",
+			votesNeededForVeto:      "502",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			outcome, err := models.NewProposalOutcome(testCase.tally, *bunbig.FromInt64(testCase.bondedTokens), testCase.params)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if outcome.QuorumReached != testCase.quorumReached {
+				t.Errorf("expected quorum reached %t, got %t", testCase.quorumReached, outcome.QuorumReached)
+			}
+			if outcome.ThresholdMet != testCase.thresholdMet {
+				t.Errorf("expected threshold met %t, got %t", testCase.thresholdMet, outcome.ThresholdMet)
+			}
+			if outcome.VetoTriggered != testCase.vetoTriggered {
+				t.Errorf("expected veto triggered %t, got %t", testCase.vetoTriggered, outcome.VetoTriggered)
+			}
+			if outcome.ProjectedResult != testCase.projectedResult {
+				t.Errorf("expected projected result %s, got %s", testCase.projectedResult, outcome.ProjectedResult)
+			}
+			if string(outcome.VotesNeededForQuorum) != testCase.votesNeededForQuorum {
+				t.Errorf("expected votes needed for quorum %s, got %s", testCase.votesNeededForQuorum, outcome.VotesNeededForQuorum)
+			}
+			if votes := formatVotesNeeded(outcome.VotesNeededForThreshold); votes != testCase.votesNeededForThreshold {
+				t.Errorf("expected votes needed for threshold %s, got %s", testCase.votesNeededForThreshold, votes)
+			}
+			if votes := formatVotesNeeded(outcome.VotesNeededForVeto); votes != testCase.votesNeededForVeto {
+				t.Errorf("expected votes needed for veto %s, got %s", testCase.votesNeededForVeto, votes)
+			}
+		})
+	}
+}
+
+func Test_NewProposalOutcomeInvalidParams(t *testing.T) {
+	testCases := []struct {
+		name   string
+		params models.GovTallyParams
+	}{
+		{"Invalid quorum", models.GovTallyParams{Quorum: "abc", Threshold: "0.5", VetoThreshold: "0.334"}},
+		{"Invalid threshold", models.GovTallyParams{Quorum: "0.4", Threshold: "", VetoThreshold: "0.334"}},
+		{"Invalid veto threshold", models.GovTallyParams{Quorum: "0.4", Threshold: "0.5", VetoThreshold: "1/3"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if _, err := models.NewProposalOutcome(newTallyResult(1, 0, 0, 0), *bunbig.FromInt64(1000), testCase.params); err == nil {
+				t.Errorf("expected error, got no error")
+			}
+		})
+	}
+}
+
+func Test_NewProposalGovernanceSummary(t *testing.T) {
+	yes := newWeightedVote(models.ProposalVoteWeightedOption{Option: models.ProposalVoteOptionYes, Weight: "1.000000000000000000"})
+	no := newWeightedVote(models.ProposalVoteWeightedOption{Option: models.ProposalVoteOptionNo, Weight: "1.000000000000000000"})
+	split := newWeightedVote(
+		models.ProposalVoteWeightedOption{Option: models.ProposalVoteOptionYes, Weight: "0.700000000000000000"},
+		models.ProposalVoteWeightedOption{Option: models.ProposalVoteOptionNoWithVeto, Weight: "0.300000000000000000"},
+	)
+
+	testCases := []struct {
+		name        string
+		delegations []models.ProposalDelegationVote
+		// Stake of yes, no, abstain and no with veto
+		stakeByOption [4]string
+		notVotedStake string
+	}{
+		{
+			name:          "No delegations",
+			delegations:   []models.ProposalDelegationVote{},
+			stakeByOption: [4]string{"0", "0", "0", "0"},
+			notVotedStake: "0",
+		},
+		{
+			name: "Validator votes",
+			delegations: []models.ProposalDelegationVote{
+				newDelegationVote("100", true, yes, nil),
+				newDelegationVote("50", true, no, nil),
+			},
+			stakeByOption: [4]string{"100", "50", "0", "0"},
+			notVotedStake: "0",
+		},
+		{
+			name: "Delegator overrides validator",
+			delegations: []models.ProposalDelegationVote{
+				newDelegationVote("100", true, yes, no),
+				newDelegationVote("50", true, nil, no),
+			},
+			stakeByOption: [4]string{"0", "150", "0", "0"},
+			notVotedStake: "0",
+		},
+		{
+			name: "Split by weight",
+			delegations: []models.ProposalDelegationVote{
+				newDelegationVote("1000", true, split, nil),
+				newDelegationVote("15", true, nil, split),
+			},
+			stakeByOption: [4]string{"710", "0", "0", "304"},
+			notVotedStake: "0",
+		},
+		{
+			name: "Untallied delegations are skipped",
+			delegations: []models.ProposalDelegationVote{
+				newDelegationVote("100", false, yes, nil),
+				newDelegationVote("100", false, nil, nil),
+				newDelegationVote("30", true, yes, nil),
+			},
+			stakeByOption: [4]string{"30", "0", "0", "0"},
+			notVotedStake: "0",
+		},
+		{
+			name: "Not voted",
+			delegations: []models.ProposalDelegationVote{
+				newDelegationVote("100", true, nil, nil),
+				newDelegationVote("20.5", true, nil, nil),
+				newDelegationVote("30", true, yes, nil),
+			},
+			stakeByOption: [4]string{"30", "0", "0", "0"},
+			notVotedStake: "120",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			summary, err := models.NewProposalGovernanceSummary(nil, testCase.delegations)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			options := []models.ProposalVoteOption{
+				models.ProposalVoteOptionYes,
+				models.ProposalVoteOptionNo,
+				models.ProposalVoteOptionAbstain,
+				models.ProposalVoteOptionNoWithVeto,
+			}
+			if len(summary.StakeByOption) != len(options) {
+				t.Fatalf("expected stake of %d options, got %d", len(options), len(summary.StakeByOption))
+			}
+			for i, stake := range summary.StakeByOption {
+				if stake.Option != options[i] {
+					t.Errorf("expected option %s, got %s", options[i], stake.Option)
+				}
+				if string(stake.Amount) != testCase.stakeByOption[i] {
+					t.Errorf("expected stake of %s %s, got %s", options[i], testCase.stakeByOption[i], stake.Amount)
+				}
+			}
+			if string(summary.NotVotedStake) != testCase.notVotedStake {
+				t.Errorf("expected not voted stake %s, got %s", testCase.notVotedStake, summary.NotVotedStake)
+			}
+		})
+	}
+}
+
+func Test_NewProposalGovernanceSummaryFail(t *testing.T) {
+	testCases := []struct {
+		name       string
+		delegation models.ProposalDelegationVote
+	}{
+		{"Invalid amount", newDelegationVote("abc", true, nil, nil)},
+		{"Invalid weight", newDelegationVote("100", true, newWeightedVote(models.ProposalVoteWeightedOption{Option: models.ProposalVoteOptionYes, Weight: "half"}), nil)},
+		{"Unknown option", newDelegationVote("100", true, newWeightedVote(models.ProposalVoteWeightedOption{Option: "VOTE_OPTION_UNSPECIFIED", Weight: "1.0"}), nil)},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if _, err := models.NewProposalGovernanceSummary(nil, []models.ProposalDelegationVote{testCase.delegation}); err == nil {
+				t.Errorf("expected error, got no error")
+			}
+		})
+	}
+}
+
+func Test_ProposalContent(t *testing.T) {
+	testCases := []struct {
+		name         string
+		proposalType models.ProposalType
+		content      string
+		expected     models.ProposalContent
+	}{
+		{
+			name:         "Parameter change",
+			proposalType: models.ProposalTypeParameterChange,
+			content:      `{"@type":"/cosmos.params.v1beta1.ParameterChangeProposal","title":"Title","changes":[{"subspace":"staking","key":"MaxValidators","value":"100"}]}`,
+			expected: &models.ParameterChangeProposalContent{
+				Changes: []models.ParameterChange{{Subspace: "staking", Key: "MaxValidators", Value: "100"}},
+			},
+		},
+		{
+			name:         "Community pool spend",
+			proposalType: models.ProposalTypeCommunityPoolSpend,
+			content:      `{"recipient":"like1abc","amount":[{"denom":"nanolike","amount":"1000"}]}`,
+			expected: &models.CommunityPoolSpendProposalContent{
+				Recipient: "like1abc",
+				Amount:    []bdjuno.DbDecCoin{{Denom: "nanolike", Amount: "1000"}},
+			},
+		},
+		{
+			name:         "Software upgrade with string height",
+			proposalType: models.ProposalTypeSoftwareUpgrade,
+			content:      `{"plan":{"name":"v2","height":"1234567","info":"https://example.com"}}`,
+			expected: &models.SoftwareUpgradeProposalContent{
+				Plan: models.SoftwareUpgradePlan{Name: "v2", Height: 1234567, Info: "https://example.com"},
+			},
+		},
+		{
+			name:         "Text has no content",
+			proposalType: models.ProposalTypeText,
+			content:      `{"title":"Title"}`,
+			expected:     nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			proposal := models.Proposal{ProposalType: string(testCase.proposalType), RawContent: json.RawMessage(testCase.content)}
+			content, err := proposal.Content()
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			expected, _ := json.Marshal(testCase.expected)
+			actual, _ := json.Marshal(content)
+			if string(actual) != string(expected) {
+				t.Errorf("expected content %s, got %s", expected, actual)
+			}
+			if testCase.expected == nil && content != nil {
+				t.Errorf("expected no content, got %T", content)
+			}
+		})
+	}
+}
+
+func Test_ProposalContentFail(t *testing.T) {
+	testCases := []struct {
+		name         string
+		proposalType models.ProposalType
+		content      string
+	}{
+		{"Not JSON", models.ProposalTypeParameterChange, `not json`},
+		{"Numeric height", models.ProposalTypeSoftwareUpgrade, `{"plan":{"name":"v2","height":1234567}}`},
+		{"Non numeric height", models.ProposalTypeSoftwareUpgrade, `{"plan":{"name":"v2","height":"abc"}}`},
+		{"Invalid amount", models.ProposalTypeCommunityPoolSpend, `{"recipient":"like1abc","amount":"1000"}`},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			proposal := models.Proposal{ProposalType: string(testCase.proposalType), RawContent: json.RawMessage(testCase.content)}
+			if _, err := proposal.Content(); err == nil {
+				t.Errorf("expected error, got no error")
+			}
+		})
+	}
+}
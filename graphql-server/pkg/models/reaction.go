@@ -2,10 +2,14 @@ package models
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/uptrace/bun"
 )
 
+// Maximum number of buckets of a reaction timeline
+const MaxReactionTimelineBuckets = 200
+
 type ReactionTargetType string
 
 const (
@@ -47,7 +51,51 @@ func (r Reaction) NodeID() NodeID {
 	return GetNodeID(r)
 }
 
+// ReactionEvent records a change of the reaction of an address to a target, Reaction is nil if the reaction is unset
+type ReactionEvent struct {
+	bun.BaseModel `bun:"table:reaction_event"`
+
+	Base
+
+	Address          string             `bun:"address,notnull"`
+	TargetID         string             `bun:"target_id,notnull"`
+	TargetType       ReactionTargetType `bun:"target_type,notnull"`
+	Reaction         *string            `bun:"reaction"`
+	PreviousReaction *string            `bun:"previous_reaction"`
+}
+
+// ReactionTimelineBucket has the counts of reactions at the end of a bucket
+type ReactionTimelineBucket struct {
+	Time      time.Time         `bun:"time"`
+	Reactions []DBReactionCount `bun:"reactions"`
+}
+
+type ReactorConnection = Connection[Reaction]
+type ReactorEdge = Edge[Reaction]
+
+// ReactionCount is the count of a reaction to a target, TargetID is the ID of the target in reactions
+type ReactionCount struct {
+	TargetType ReactionTargetType
+	TargetID   string
+	Reaction   string
+	Count      int
+}
+
 type DBReactionCount struct {
 	Reaction string `json:"reaction"`
 	Count    int    `json:"count"`
 }
+
+// ReactionKind is a reaction in the catalogue of a target type
+type ReactionKind struct {
+	bun.BaseModel `bun:"table:reaction_kind"`
+
+	TargetType ReactionTargetType `bun:"target_type,pk"`
+	Reaction   string             `bun:"reaction,pk"`
+	Emoji      *string            `bun:"emoji"`
+	Label      string             `bun:"label,notnull"`
+	Position   int                `bun:"position,notnull"`
+	Deprecated bool               `bun:"deprecated,notnull"`
+	CreatedAt  time.Time          `bun:"created_at,notnull"`
+	UpdatedAt  time.Time          `bun:"updated_at,notnull"`
+}
@@ -1,14 +1,63 @@
 package models
 
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Cursor is an opaque relay cursor holding the values of the sort keys of an edge
+type Cursor []string
+
+func (c Cursor) String() string {
+	encoded, _ := json.Marshal([]string(c))
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+func ParseCursor(str string) (Cursor, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(str)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %s", str)
+	}
+
+	var values []string
+	if err := json.Unmarshal(decoded, &values); err != nil || len(values) == 0 {
+		return nil, fmt.Errorf("invalid cursor: %s", str)
+	}
+
+	return Cursor(values), nil
+}
+
+func (c *Cursor) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("cursor must be a string")
+	}
+
+	cursor, err := ParseCursor(str)
+	if err != nil {
+		return err
+	}
+
+	*c = cursor
+	return nil
+}
+
+func (c Cursor) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(c.String()))
+}
+
 type PageInfo struct {
-	StartCursor     *string `json:"startCursor"`
-	EndCursor       *string `json:"endCursor"`
+	StartCursor     *Cursor `json:"startCursor"`
+	EndCursor       *Cursor `json:"endCursor"`
 	HasNextPage     bool    `json:"hasNextPage"`
 	HasPreviousPage bool    `json:"hasPreviousPage"`
 }
 
 type Edge[T any] struct {
-	Cursor string `json:"cursor"`
+	Cursor Cursor `json:"cursor"`
 	Node   T      `json:"node"`
 }
 
@@ -18,26 +67,27 @@ type Connection[T any] struct {
 	TotalCount int       `json:"totalCount"`
 }
 
-func NewConnection[T any](models []T, cursorFn func(model T) string) Connection[T] {
+// NewConnection creates a connection of models, the cursor of each model is at the same index in cursors
+func NewConnection[T any](models []T, cursors []Cursor) Connection[T] {
 	edges := make([]Edge[T], 0, len(models))
-	for _, model := range models {
+	for i, model := range models {
 		edges = append(edges, Edge[T]{
-			Cursor: cursorFn(model),
+			Cursor: cursors[i],
 			Node:   model,
 		})
 	}
 
-	var startCursor, endCursor string
+	var startCursor, endCursor *Cursor
 	if len(edges) > 0 {
-		startCursor = edges[0].Cursor
-		endCursor = edges[len(edges)-1].Cursor
+		startCursor = &edges[0].Cursor
+		endCursor = &edges[len(edges)-1].Cursor
 	}
 
 	return Connection[T]{
 		Edges: edges,
 		PageInfo: PageInfo{
-			StartCursor: &startCursor,
-			EndCursor:   &endCursor,
+			StartCursor: startCursor,
+			EndCursor:   endCursor,
 		},
 	}
 }
@@ -0,0 +1,86 @@
+package models_test
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	"github.com/oursky/likedao/pkg/models"
+)
+
+func Test_CursorRoundTrip(t *testing.T) {
+	testCases := []struct {
+		name   string
+		cursor models.Cursor
+	}{
+		{"Single value", models.Cursor{"1"}},
+		{"Multiple values", models.Cursor{"2022-06-01T12:00:00Z", "42"}},
+		{"Empty and special values", models.Cursor{"", "a,b", "\"quoted\"", "測試"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			parsed, err := models.ParseCursor(testCase.cursor.String())
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if len(parsed) != len(testCase.cursor) {
+				t.Fatalf("expected %v, got %v", testCase.cursor, parsed)
+			}
+			for i := range parsed {
+				if parsed[i] != testCase.cursor[i] {
+					t.Errorf("expected %v, got %v", testCase.cursor, parsed)
+				}
+			}
+		})
+	}
+}
+
+func Test_CursorGQL(t *testing.T) {
+	cursor := models.Cursor{"1", "2"}
+
+	var buf bytes.Buffer
+	cursor.MarshalGQL(&buf)
+	str, err := strconv.Unquote(buf.String())
+	if err != nil {
+		t.Fatalf("expected a quoted string, got %s", buf.String())
+	}
+
+	var unmarshalled models.Cursor
+	if err := unmarshalled.UnmarshalGQL(str); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if unmarshalled.String() != cursor.String() {
+		t.Errorf("expected %v, got %v", cursor, unmarshalled)
+	}
+
+	if err := unmarshalled.UnmarshalGQL(1); err == nil {
+		t.Errorf("expected error for non-string cursor, got no error")
+	}
+}
+
+func Test_ParseCursorFail(t *testing.T) {
+	testCases := []struct {
+		name string
+		str  string
+	}{
+		{"Empty string", ""},
+		{"Not base64", "not a cursor!"},
+		// base64 of 1
+		{"Not an array", "MQ"},
+		// base64 of [1]
+		{"Not an array of strings", "WzFd"},
+		// base64 of []
+		{"Empty array", "W10"},
+		// base64 of ["1"] with standard padding
+		{"Padded base64", "WyIxIl0="},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if _, err := models.ParseCursor(testCase.str); err == nil {
+				t.Errorf("expected error for %s, got no error", testCase.str)
+			}
+		})
+	}
+}
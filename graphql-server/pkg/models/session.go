@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type Session struct {
+	bun.BaseModel `bun:"table:session"`
+
+	Base
+
+	Address    string    `bun:"address,notnull"`
+	UserAgent  string    `bun:"user_agent,notnull"`
+	Revoked    bool      `bun:"revoked,notnull"`
+	ExpiresAt  time.Time `bun:"expires_at,notnull"`
+	LastSeenAt time.Time `bun:"last_seen_at,notnull"`
+}
+
+func (s Session) IsNode() {}
+
+func (s Session) NodeID() NodeID {
+	return GetNodeID(s)
+}
+
+func (s Session) IsActive() bool {
+	return !s.Revoked && s.ExpiresAt.After(time.Now())
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+
+	bdjuno "github.com/forbole/bdjuno/database/types"
+	"github.com/uptrace/bun"
+)
+
+type Transaction struct {
+	bun.BaseModel `bun:"table:transaction"`
+
+	Hash       string         `bun:"column:hash,pk"`
+	Height     int64          `bun:"column:height,notnull"`
+	Success    bool           `bun:"column:success,notnull"`
+	Memo       string         `bun:"column:memo"`
+	Signatures []string       `bun:"column:signatures,notnull,array"`
+	Fee        TransactionFee `bun:"column:fee,notnull,type:jsonb"`
+	GasWanted  int64          `bun:"column:gas_wanted"`
+	GasUsed    int64          `bun:"column:gas_used"`
+	RawLog     string         `bun:"column:raw_log"`
+}
+
+func (t Transaction) IsNode() {}
+func (t Transaction) NodeID() NodeID {
+	return GetNodeID(t)
+}
+
+// TransactionFee is the fee of auth info stored as JSON by bdjuno
+type TransactionFee struct {
+	Amount []bdjuno.DbDecCoin `json:"amount"`
+}
+
+type TransactionConnection = Connection[Transaction]
+type TransactionEdge = Edge[Transaction]
+
+type TransactionMessage struct {
+	bun.BaseModel `bun:"table:message"`
+
+	TransactionHash           string          `bun:"column:transaction_hash,notnull"`
+	Index                     int             `bun:"column:index,notnull"`
+	Type                      string          `bun:"column:type,notnull"`
+	Value                     json.RawMessage `bun:"column:value,notnull,type:jsonb"`
+	InvolvedAccountsAddresses []string        `bun:"column:involved_accounts_addresses,array"`
+}
+
+// Content decodes value of the message, nil is returned for message types that are not decoded
+func (m TransactionMessage) Content() (TransactionMessageContent, error) {
+	var content TransactionMessageContent
+	switch m.Type {
+	case "cosmos.bank.v1beta1.MsgSend":
+		content = &MsgSend{}
+	case "cosmos.staking.v1beta1.MsgDelegate":
+		content = &MsgDelegate{}
+	case "cosmos.gov.v1beta1.MsgVote":
+		content = &MsgVote{}
+	case "cosmos.gov.v1beta1.MsgDeposit":
+		content = &MsgDeposit{}
+	case "cosmos.gov.v1beta1.MsgSubmitProposal":
+		content = &MsgSubmitProposal{}
+	default:
+		return nil, nil
+	}
+
+	if err := json.Unmarshal(m.Value, content); err != nil {
+		return nil, fmt.Errorf("invalid %s message: %w", m.Type, err)
+	}
+	return content, nil
+}
+
+// Messages are stored in proto JSON, where 64-bit integers are encoded as strings
+
+type MsgSend struct {
+	FromAddress string             `json:"from_address"`
+	ToAddress   string             `json:"to_address"`
+	Amount      []bdjuno.DbDecCoin `json:"amount"`
+}
+
+func (m MsgSend) IsTransactionMessageContent() {}
+
+type MsgDelegate struct {
+	DelegatorAddress string           `json:"delegator_address"`
+	ValidatorAddress string           `json:"validator_address"`
+	Amount           bdjuno.DbDecCoin `json:"amount"`
+}
+
+func (m MsgDelegate) IsTransactionMessageContent() {}
+
+type MsgVote struct {
+	ProposalID int                `json:"proposal_id,string"`
+	Voter      string             `json:"voter"`
+	Option     ProposalVoteOption `json:"option"`
+}
+
+func (m MsgVote) IsTransactionMessageContent() {}
+
+type MsgDeposit struct {
+	ProposalID int                `json:"proposal_id,string"`
+	Depositor  string             `json:"depositor"`
+	Amount     []bdjuno.DbDecCoin `json:"amount"`
+}
+
+func (m MsgDeposit) IsTransactionMessageContent() {}
+
+type MsgSubmitProposal struct {
+	Content        MsgSubmitProposalContent `json:"content"`
+	InitialDeposit []bdjuno.DbDecCoin       `json:"initial_deposit"`
+	Proposer       string                   `json:"proposer"`
+}
+
+func (m MsgSubmitProposal) IsTransactionMessageContent() {}
+
+type MsgSubmitProposalContent struct {
+	Type        string `json:"@type"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
@@ -110,3 +110,185 @@ type DBRelativeTotalProposalCount struct {
 	ConsensusAddress string `json:"consensus_address"`
 	ProposalCount    int    `json:"proposal_count"`
 }
+
+// ValidatorSnapshot records the state of a validator at a height, as bdjuno only keeps the latest state
+type ValidatorSnapshot struct {
+	bun.BaseModel `bun:"table:validator_snapshot"`
+
+	Base
+
+	ConsensusAddress    string     `bun:"consensus_address,notnull"`
+	Height              int64      `bun:"height,notnull"`
+	Timestamp           time.Time  `bun:"timestamp,notnull"`
+	VotingPower         bunbig.Int `bun:"voting_power,notnull"`
+	RelativeVotingPower float64    `bun:"relative_voting_power,notnull"`
+	Commission          float64    `bun:"commission,notnull"`
+	MissedBlocksCounter int64      `bun:"missed_blocks_counter,notnull"`
+	Uptime              float64    `bun:"uptime,notnull"`
+
+	// Start of the bucket the snapshot is in, only selected when querying history
+	Bucket time.Time `bun:"bucket,scanonly"`
+}
+
+func NewValidatorSnapshot(validator Validator, block Block) ValidatorSnapshot {
+	snapshot := ValidatorSnapshot{
+		ConsensusAddress: validator.ConsensusAddress,
+		Height:           int64(block.Height),
+		Timestamp:        block.Timestamp.UTC(),
+	}
+	if validator.VotingPower != nil {
+		snapshot.VotingPower = validator.VotingPower.VotingPower
+		snapshot.RelativeVotingPower = validator.VotingPower.RelativeVotingPower
+	}
+	if validator.Commission != nil {
+		snapshot.Commission, _ = validator.Commission.Commission.ToFloat64()
+	}
+	if validator.SigningInfo != nil {
+		snapshot.MissedBlocksCounter = validator.SigningInfo.MissedBlocksCounter
+		snapshot.Uptime = validator.SigningInfo.Uptime
+	}
+	return snapshot
+}
+
+// Upper bound of buckets a single validator history query may cover
+const MaxValidatorHistoryBuckets = 1000
+
+// TruncUnit returns the unit of date_trunc that buckets snapshots by the interval
+func (i ValidatorHistoryInterval) TruncUnit() string {
+	switch i {
+	case ValidatorHistoryIntervalDay:
+		return "day"
+	case ValidatorHistoryIntervalWeek:
+		return "week"
+	default:
+		return "hour"
+	}
+}
+
+func (i ValidatorHistoryInterval) Duration() time.Duration {
+	switch i {
+	case ValidatorHistoryIntervalDay:
+		return 24 * time.Hour
+	case ValidatorHistoryIntervalWeek:
+		return 7 * 24 * time.Hour
+	default:
+		return time.Hour
+	}
+}
+
+// ValidatorGovernanceRecordEntry is a proposal voted on since the start of a validator, with the vote of the validator if any
+type ValidatorGovernanceRecordEntry struct {
+	ConsensusAddress string              `bun:"consensus_address"`
+	VoterAddress     string              `bun:"voter_address"`
+	ProposalID       int                 `bun:"proposal_id"`
+	ProposalStatus   ProposalStatus      `bun:"proposal_status"`
+	VoteOption       *ProposalVoteOption `bun:"vote_option"`
+	// Number of all voters of the proposal and those who voted at a lower height than the validator
+	VoterCount        int `bun:"voter_count"`
+	EarlierVoterCount int `bun:"earlier_voter_count"`
+}
+
+func (e ValidatorGovernanceRecordEntry) Voted() bool {
+	return e.VoteOption != nil
+}
+
+// HasOutcome returns whether voting on the proposal has ended
+func (e ValidatorGovernanceRecordEntry) HasOutcome() bool {
+	switch e.ProposalStatus {
+	case ProposalStatusPassed, ProposalStatusRejected, ProposalStatusFailed:
+		return true
+	}
+	return false
+}
+
+// AgreedWithOutcome returns whether the validator voted for the outcome of the proposal,
+// a failed proposal has passed but failed to execute
+func (e ValidatorGovernanceRecordEntry) AgreedWithOutcome() *bool {
+	if !e.Voted() || !e.HasOutcome() {
+		return nil
+	}
+
+	agreed := *e.VoteOption == ProposalVoteOptionYes
+	if e.ProposalStatus == ProposalStatusRejected {
+		agreed = *e.VoteOption == ProposalVoteOptionNo || *e.VoteOption == ProposalVoteOptionNoWithVeto
+	}
+	return &agreed
+}
+
+// VotedBeforeMajority returns whether the validator voted before more than half of all voters of the proposal
+func (e ValidatorGovernanceRecordEntry) VotedBeforeMajority() *bool {
+	if !e.Voted() || !e.HasOutcome() {
+		return nil
+	}
+
+	before := e.EarlierVoterCount*2 < e.VoterCount
+	return &before
+}
+
+type ValidatorGovernanceRecordEdge = Edge[ValidatorGovernanceRecordEntry]
+
+// ValidatorGovernanceRecord holds entries of all proposals since the start of a validator, latest proposal first
+type ValidatorGovernanceRecord struct {
+	Entries []ValidatorGovernanceRecordEntry
+}
+
+func (r ValidatorGovernanceRecord) ParticipationRate() float64 {
+	if len(r.Entries) == 0 {
+		return 0
+	}
+
+	voted := 0
+	for _, entry := range r.Entries {
+		if entry.Voted() {
+			voted++
+		}
+	}
+	return float64(voted) / float64(len(r.Entries))
+}
+
+// AgreementRate returns the ratio of votes agreeing with the outcome over votes on proposals with an outcome,
+// nil if there are no such votes
+func (r ValidatorGovernanceRecord) AgreementRate() *float64 {
+	agreed, total := 0, 0
+	for _, entry := range r.Entries {
+		agreedWithOutcome := entry.AgreedWithOutcome()
+		if agreedWithOutcome == nil {
+			continue
+		}
+		total++
+		if *agreedWithOutcome {
+			agreed++
+		}
+	}
+
+	if total == 0 {
+		return nil
+	}
+	rate := float64(agreed) / float64(total)
+	return &rate
+}
+
+func (r ValidatorGovernanceRecord) VotedBeforeMajorityCount() int {
+	return r.countVotedBeforeMajority(true)
+}
+
+func (r ValidatorGovernanceRecord) VotedAfterMajorityCount() int {
+	return r.countVotedBeforeMajority(false)
+}
+
+func (r ValidatorGovernanceRecord) countVotedBeforeMajority(before bool) int {
+	count := 0
+	for _, entry := range r.Entries {
+		votedBeforeMajority := entry.VotedBeforeMajority()
+		if votedBeforeMajority != nil && *votedBeforeMajority == before {
+			count++
+		}
+	}
+	return count
+}
+
+// ValidatorGovernanceRecordConnection is a page of entries of a governance record with the statistics of the whole record
+type ValidatorGovernanceRecordConnection struct {
+	Connection[ValidatorGovernanceRecordEntry]
+	ValidatorGovernanceRecord
+}
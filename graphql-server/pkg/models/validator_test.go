@@ -0,0 +1,189 @@
+package models_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/oursky/likedao/pkg/models"
+)
+
+func voteOptionPtr(option models.ProposalVoteOption) *models.ProposalVoteOption {
+	return &option
+}
+
+func formatOptionalBool(value *bool) string {
+	if value == nil {
+		return "nil"
+	}
+	return strconv.FormatBool(*value)
+}
+
+func Test_ValidatorGovernanceRecordEntry(t *testing.T) {
+	yes := voteOptionPtr(models.ProposalVoteOptionYes)
+	no := voteOptionPtr(models.ProposalVoteOptionNo)
+	noWithVeto := voteOptionPtr(models.ProposalVoteOptionNoWithVeto)
+	abstain := voteOptionPtr(models.ProposalVoteOptionAbstain)
+
+	testCases := []struct {
+		name                string
+		entry               models.ValidatorGovernanceRecordEntry
+		agreedWithOutcome   string
+		votedBeforeMajority string
+	}{
+		{
+			name:                "Yes on passed",
+			entry:               models.ValidatorGovernanceRecordEntry{ProposalStatus: models.ProposalStatusPassed, VoteOption: yes, VoterCount: 10, EarlierVoterCount: 0},
+			agreedWithOutcome:   "true",
+			votedBeforeMajority: "true",
+		},
+		{
+			name:                "No on passed",
+			entry:               models.ValidatorGovernanceRecordEntry{ProposalStatus: models.ProposalStatusPassed, VoteOption: no, VoterCount: 10, EarlierVoterCount: 9},
+			agreedWithOutcome:   "false",
+			votedBeforeMajority: "false",
+		},
+		{
+			name:                "Yes on failed",
+			entry:               models.ValidatorGovernanceRecordEntry{ProposalStatus: models.ProposalStatusFailed, VoteOption: yes, VoterCount: 10, EarlierVoterCount: 4},
+			agreedWithOutcome:   "true",
+			votedBeforeMajority: "true",
+		},
+		{
+			name:                "No on rejected",
+			entry:               models.ValidatorGovernanceRecordEntry{ProposalStatus: models.ProposalStatusRejected, VoteOption: no, VoterCount: 10, EarlierVoterCount: 4},
+			agreedWithOutcome:   "true",
+			votedBeforeMajority: "true",
+		},
+		{
+			name:                "No with veto on rejected",
+			entry:               models.ValidatorGovernanceRecordEntry{ProposalStatus: models.ProposalStatusRejected, VoteOption: noWithVeto, VoterCount: 10, EarlierVoterCount: 6},
+			agreedWithOutcome:   "true",
+			votedBeforeMajority: "false",
+		},
+		{
+			name:                "Yes on rejected",
+			entry:               models.ValidatorGovernanceRecordEntry{ProposalStatus: models.ProposalStatusRejected, VoteOption: yes, VoterCount: 10, EarlierVoterCount: 0},
+			agreedWithOutcome:   "false",
+			votedBeforeMajority: "true",
+		},
+		{
+			name:                "Abstain on passed",
+			entry:               models.ValidatorGovernanceRecordEntry{ProposalStatus: models.ProposalStatusPassed, VoteOption: abstain, VoterCount: 10, EarlierVoterCount: 0},
+			agreedWithOutcome:   "false",
+			votedBeforeMajority: "true",
+		},
+		{
+			name:                "Tie at half of voters is not before majority",
+			entry:               models.ValidatorGovernanceRecordEntry{ProposalStatus: models.ProposalStatusPassed, VoteOption: yes, VoterCount: 10, EarlierVoterCount: 5},
+			agreedWithOutcome:   "true",
+			votedBeforeMajority: "false",
+		},
+		{
+			name:                "Below half of odd voters is before majority",
+			entry:               models.ValidatorGovernanceRecordEntry{ProposalStatus: models.ProposalStatusPassed, VoteOption: yes, VoterCount: 5, EarlierVoterCount: 2},
+			agreedWithOutcome:   "true",
+			votedBeforeMajority: "true",
+		},
+		{
+			name:                "Not voted",
+			entry:               models.ValidatorGovernanceRecordEntry{ProposalStatus: models.ProposalStatusPassed, VoterCount: 10},
+			agreedWithOutcome:   "nil",
+			votedBeforeMajority: "nil",
+		},
+		{
+			name:                "Voting period",
+			entry:               models.ValidatorGovernanceRecordEntry{ProposalStatus: models.ProposalStatusVotingPeriod, VoteOption: yes, VoterCount: 10},
+			agreedWithOutcome:   "nil",
+			votedBeforeMajority: "nil",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if agreed := formatOptionalBool(testCase.entry.AgreedWithOutcome()); agreed != testCase.agreedWithOutcome {
+				t.Errorf("expected agreed with outcome %s, got %s", testCase.agreedWithOutcome, agreed)
+			}
+			if before := formatOptionalBool(testCase.entry.VotedBeforeMajority()); before != testCase.votedBeforeMajority {
+				t.Errorf("expected voted before majority %s, got %s", testCase.votedBeforeMajority, before)
+			}
+		})
+	}
+}
+
+func Test_ValidatorGovernanceRecord(t *testing.T) {
+	yes := voteOptionPtr(models.ProposalVoteOptionYes)
+	no := voteOptionPtr(models.ProposalVoteOptionNo)
+
+	testCases := []struct {
+		name                     string
+		entries                  []models.ValidatorGovernanceRecordEntry
+		participationRate        float64
+		agreementRate            string
+		votedBeforeMajorityCount int
+		votedAfterMajorityCount  int
+	}{
+		{
+			name:              "Empty",
+			entries:           []models.ValidatorGovernanceRecordEntry{},
+			participationRate: 0,
+			agreementRate:     "nil",
+		},
+		{
+			name: "Not voted",
+			entries: []models.ValidatorGovernanceRecordEntry{
+				{ProposalStatus: models.ProposalStatusPassed, VoterCount: 10},
+				{ProposalStatus: models.ProposalStatusRejected, VoterCount: 10},
+			},
+			participationRate: 0,
+			agreementRate:     "nil",
+		},
+		{
+			name: "Voted only in voting period",
+			entries: []models.ValidatorGovernanceRecordEntry{
+				{ProposalStatus: models.ProposalStatusVotingPeriod, VoteOption: yes, VoterCount: 10},
+				{ProposalStatus: models.ProposalStatusPassed, VoterCount: 10},
+			},
+			participationRate: 0.5,
+			agreementRate:     "nil",
+		},
+		{
+			name: "Mixed outcomes",
+			entries: []models.ValidatorGovernanceRecordEntry{
+				{ProposalStatus: models.ProposalStatusVotingPeriod, VoteOption: no, VoterCount: 10},
+				{ProposalStatus: models.ProposalStatusPassed, VoteOption: yes, VoterCount: 10, EarlierVoterCount: 1},
+				{ProposalStatus: models.ProposalStatusRejected, VoteOption: yes, VoterCount: 10, EarlierVoterCount: 5},
+				{ProposalStatus: models.ProposalStatusFailed, VoteOption: yes, VoterCount: 10, EarlierVoterCount: 8},
+				{ProposalStatus: models.ProposalStatusRejected, VoterCount: 10},
+			},
+			participationRate:        0.8,
+			agreementRate:            "0.6667",
+			votedBeforeMajorityCount: 1,
+			votedAfterMajorityCount:  2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			record := models.ValidatorGovernanceRecord{Entries: testCase.entries}
+
+			if rate := record.ParticipationRate(); rate != testCase.participationRate {
+				t.Errorf("expected participation rate %v, got %v", testCase.participationRate, rate)
+			}
+
+			agreementRate := "nil"
+			if rate := record.AgreementRate(); rate != nil {
+				agreementRate = strconv.FormatFloat(*rate, 'f', 4, 64)
+			}
+			if agreementRate != testCase.agreementRate {
+				t.Errorf("expected agreement rate %s, got %s", testCase.agreementRate, agreementRate)
+			}
+
+			if count := record.VotedBeforeMajorityCount(); count != testCase.votedBeforeMajorityCount {
+				t.Errorf("expected voted before majority count %d, got %d", testCase.votedBeforeMajorityCount, count)
+			}
+			if count := record.VotedAfterMajorityCount(); count != testCase.votedAfterMajorityCount {
+				t.Errorf("expected voted after majority count %d, got %d", testCase.votedAfterMajorityCount, count)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package mutators
+
+import (
+	"context"
+	"time"
+
+	"github.com/oursky/likedao/pkg/models"
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+type IAuthNonceMutator interface {
+	ConsumeNonce(nonce string, expiresAt time.Time) (bool, error)
+	DeleteExpiredNonces() (int64, error)
+}
+
+type AuthNonceMutator struct {
+	ctx     context.Context
+	session *bun.DB
+}
+
+func NewAuthNonceMutator(ctx context.Context, session *bun.DB) IAuthNonceMutator {
+	return &AuthNonceMutator{ctx: ctx, session: session}
+}
+
+// ConsumeNonce records the nonce as consumed in a single statement, so concurrent requests
+// presenting the same nonce cannot both succeed. Returns false if the nonce has been consumed
+func (q *AuthNonceMutator) ConsumeNonce(nonce string, expiresAt time.Time) (bool, error) {
+	authNonce := &models.AuthNonce{
+		Nonce:     nonce,
+		ExpiresAt: expiresAt.UTC(),
+	}
+	res, err := q.session.NewInsert().
+		Model(authNonce).
+		On("CONFLICT (nonce) DO NOTHING").
+		Exec(q.ctx)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	return affected > 0, nil
+}
+
+func (q *AuthNonceMutator) DeleteExpiredNonces() (int64, error) {
+	res, err := q.session.NewDelete().
+		Model((*models.AuthNonce)(nil)).
+		Where("expires_at <= ?", models.NewTimestamp()).
+		Exec(q.ctx)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	return affected, nil
+}
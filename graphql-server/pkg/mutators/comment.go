@@ -0,0 +1,93 @@
+package mutators
+
+import (
+	"context"
+
+	"github.com/oursky/likedao/pkg/models"
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+type ICommentMutator interface {
+	CreateComment(proposalID int, parentID *string, author string, body string) (*models.Comment, error)
+	UpdateComment(id string, author string, body string) (*models.Comment, error)
+	DeleteComment(id string, author string) (*models.Comment, error)
+}
+
+type CommentMutator struct {
+	ctx     context.Context
+	session *bun.DB
+}
+
+func NewCommentMutator(ctx context.Context, session *bun.DB) ICommentMutator {
+	return &CommentMutator{ctx: ctx, session: session}
+}
+
+func (q *CommentMutator) CreateComment(proposalID int, parentID *string, author string, body string) (*models.Comment, error) {
+	comment := &models.Comment{
+		ProposalID: proposalID,
+		ParentID:   parentID,
+		Author:     author,
+		Body:       body,
+	}
+	_, err := q.session.NewInsert().Model(comment).Exec(q.ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return comment, nil
+}
+
+func (q *CommentMutator) UpdateComment(id string, author string, body string) (*models.Comment, error) {
+	now := models.NewTimestamp()
+	comment := new(models.Comment)
+	res, err := q.session.NewUpdate().
+		Model(comment).
+		Set("body = ?", body).
+		Set("edited_at = ?", now).
+		Set("updated_at = ?", now).
+		Where("id = ? AND author = ? AND deleted_at IS NULL", id, author).
+		Returning("*").
+		Exec(q.ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	// Comment does not exist, is deleted or belongs to another address
+	if affected == 0 {
+		return nil, nil
+	}
+
+	return comment, nil
+}
+
+// DeleteComment soft deletes the comment so that its replies are kept
+func (q *CommentMutator) DeleteComment(id string, author string) (*models.Comment, error) {
+	now := models.NewTimestamp()
+	comment := new(models.Comment)
+	res, err := q.session.NewUpdate().
+		Model(comment).
+		Set("deleted_at = ?", now).
+		Set("updated_at = ?", now).
+		Where("id = ? AND author = ? AND deleted_at IS NULL", id, author).
+		Returning("*").
+		Exec(q.ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	// Comment does not exist, is already deleted or belongs to another address
+	if affected == 0 {
+		return nil, nil
+	}
+
+	return comment, nil
+}
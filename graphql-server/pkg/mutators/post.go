@@ -0,0 +1,56 @@
+package mutators
+
+import (
+	"context"
+
+	"github.com/oursky/likedao/pkg/models"
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+type IPostMutator interface {
+	UpsertPost(post *models.Post) (*models.Post, error)
+}
+
+type PostMutator struct {
+	ctx     context.Context
+	session *bun.DB
+}
+
+func NewPostMutator(ctx context.Context, session *bun.DB) IPostMutator {
+	return &PostMutator{ctx: ctx, session: session}
+}
+
+// UpsertPost inserts the post or updates the existing post of the same ISCN ID,
+// returns nil if the existing post is not older than the given post
+func (q *PostMutator) UpsertPost(post *models.Post) (*models.Post, error) {
+	res, err := q.session.NewInsert().
+		Model(post).
+		On("CONFLICT (iscn_id) DO UPDATE").
+		Set("version = EXCLUDED.version").
+		Set("owner = EXCLUDED.owner").
+		Set("title = EXCLUDED.title").
+		Set("description = EXCLUDED.description").
+		Set("url = EXCLUDED.url").
+		Set("content_fingerprints = EXCLUDED.content_fingerprints").
+		Set("proposal_id = EXCLUDED.proposal_id").
+		Set("record_timestamp = EXCLUDED.record_timestamp").
+		Set("record = EXCLUDED.record").
+		Set("updated_at = EXCLUDED.updated_at").
+		Where("post.version < EXCLUDED.version").
+		Returning("*").
+		Exec(q.ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if affected == 0 {
+		return nil, nil
+	}
+
+	return post, nil
+}
@@ -2,6 +2,7 @@ package mutators
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/oursky/likedao/pkg/models"
 	"github.com/pkg/errors"
@@ -22,14 +23,27 @@ func NewReactionMutator(ctx context.Context, session *bun.DB) IReactionMutator {
 	return &ReactionMutator{ctx: ctx, session: session}
 }
 
+// lockReaction serializes changes to the reaction of an address to a target until the transaction ends.
+// Row locks cannot be used as the reaction may not exist yet, in which case concurrent transactions would both
+// see no previous reaction
+func lockReaction(ctx context.Context, tx bun.Tx, targetID string, targetType models.ReactionTargetType, address string) error {
+	_, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext(?))", address+"/"+string(targetType)+"/"+targetID)
+	return err
+}
+
 func (q *ReactionMutator) UnsetReaction(targetID string, targetType models.ReactionTargetType, address string) (*models.Reaction, error) {
 	reactionModel := new(models.Reaction)
 
 	err := q.session.RunInTx(q.ctx, nil, func(ctx context.Context, tx bun.Tx) error {
-		err := q.session.NewSelect().
+		if err := lockReaction(ctx, tx, targetID, targetType, address); err != nil {
+			return err
+		}
+
+		err := tx.NewSelect().
 			Model(reactionModel).
 			Where("target_id = ? AND target_type = ? AND address = ?", targetID, targetType, address).
-			Scan(q.ctx)
+			For("UPDATE").
+			Scan(ctx)
 
 		if err != nil {
 			return err
@@ -38,8 +52,20 @@ func (q *ReactionMutator) UnsetReaction(targetID string, targetType models.React
 		_, err = tx.NewDelete().
 			Model((*models.Reaction)(nil)).
 			Where("id = ?", reactionModel.ID).
-			Exec(q.ctx)
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
 
+		_, err = tx.NewInsert().
+			Model(&models.ReactionEvent{
+				Address:          address,
+				TargetID:         targetID,
+				TargetType:       targetType,
+				Reaction:         nil,
+				PreviousReaction: &reactionModel.Reaction,
+			}).
+			Exec(ctx)
 		return err
 	})
 
@@ -59,13 +85,49 @@ func (q *ReactionMutator) SetReaction(targetID string, targetType models.Reactio
 	}
 
 	err := q.session.RunInTx(q.ctx, nil, func(ctx context.Context, tx bun.Tx) error {
-		_, err := q.session.NewInsert().
+		if err := lockReaction(ctx, tx, targetID, targetType, address); err != nil {
+			return err
+		}
+
+		var previousReaction *string
+		var existingReaction string
+		err := tx.NewSelect().
+			Model((*models.Reaction)(nil)).
+			Column("reaction").
+			Where("target_id = ? AND target_type = ? AND address = ?", targetID, targetType, address).
+			For("UPDATE").
+			Scan(ctx, &existingReaction)
+		if err == nil {
+			previousReaction = &existingReaction
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		_, err = tx.NewInsert().
 			Model(reactionModel).
 			On("CONFLICT (address, target_type, target_id) DO UPDATE").
 			Set("reaction = EXCLUDED.reaction").
+			Set("updated_at = EXCLUDED.updated_at").
 			Returning("*").
-			Exec(q.ctx)
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
 
+		// Setting the same reaction again does not change anything
+		if previousReaction != nil && *previousReaction == reaction {
+			return nil
+		}
+
+		_, err = tx.NewInsert().
+			Model(&models.ReactionEvent{
+				Address:          address,
+				TargetID:         targetID,
+				TargetType:       targetType,
+				Reaction:         &reaction,
+				PreviousReaction: previousReaction,
+			}).
+			Exec(ctx)
 		return err
 	})
 
@@ -74,5 +136,4 @@ func (q *ReactionMutator) SetReaction(targetID string, targetType models.Reactio
 	}
 
 	return reactionModel, nil
-
 }
@@ -0,0 +1,97 @@
+package mutators
+
+import (
+	"context"
+	"time"
+
+	"github.com/oursky/likedao/pkg/models"
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+// Minimum interval between updates to the last seen time of a session, to avoid a write on every request
+const sessionLastSeenInterval = time.Minute
+
+type ISessionMutator interface {
+	CreateSession(address string, userAgent string, expiresAt time.Time) (*models.Session, error)
+	TouchSession(id string) error
+	RevokeSession(id string, address string) (*models.Session, error)
+	RevokeAllSessions(address string) ([]models.Session, error)
+}
+
+type SessionMutator struct {
+	ctx     context.Context
+	session *bun.DB
+}
+
+func NewSessionMutator(ctx context.Context, session *bun.DB) ISessionMutator {
+	return &SessionMutator{ctx: ctx, session: session}
+}
+
+func (q *SessionMutator) CreateSession(address string, userAgent string, expiresAt time.Time) (*models.Session, error) {
+	session := &models.Session{
+		Address:    address,
+		UserAgent:  userAgent,
+		ExpiresAt:  expiresAt.UTC(),
+		LastSeenAt: models.NewTimestamp(),
+	}
+	_, err := q.session.NewInsert().Model(session).Exec(q.ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return session, nil
+}
+
+func (q *SessionMutator) TouchSession(id string) error {
+	now := models.NewTimestamp()
+	_, err := q.session.NewUpdate().
+		Model((*models.Session)(nil)).
+		Set("last_seen_at = ?", now).
+		Where("id = ?", id).
+		Where("last_seen_at < ?", now.Add(-sessionLastSeenInterval)).
+		Exec(q.ctx)
+
+	return errors.WithStack(err)
+}
+
+func (q *SessionMutator) RevokeSession(id string, address string) (*models.Session, error) {
+	session := new(models.Session)
+	res, err := q.session.NewUpdate().
+		Model(session).
+		Set("revoked = ?", true).
+		Set("updated_at = ?", models.NewTimestamp()).
+		Where("id = ? AND address = ?", id, address).
+		Returning("*").
+		Exec(q.ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	// Session does not exist or belongs to another address
+	if affected == 0 {
+		return nil, nil
+	}
+
+	return session, nil
+}
+
+func (q *SessionMutator) RevokeAllSessions(address string) ([]models.Session, error) {
+	sessions := make([]models.Session, 0)
+	_, err := q.session.NewUpdate().
+		Model(&sessions).
+		Set("revoked = ?", true).
+		Set("updated_at = ?", models.NewTimestamp()).
+		Where("address = ? AND revoked = ?", address, false).
+		Returning("*").
+		Exec(q.ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return sessions, nil
+}
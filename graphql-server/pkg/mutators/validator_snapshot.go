@@ -0,0 +1,45 @@
+package mutators
+
+import (
+	"context"
+
+	"github.com/oursky/likedao/pkg/models"
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+type IValidatorSnapshotMutator interface {
+	CreateSnapshots(snapshots []models.ValidatorSnapshot) (int64, error)
+}
+
+type ValidatorSnapshotMutator struct {
+	ctx     context.Context
+	session *bun.DB
+}
+
+func NewValidatorSnapshotMutator(ctx context.Context, session *bun.DB) IValidatorSnapshotMutator {
+	return &ValidatorSnapshotMutator{ctx: ctx, session: session}
+}
+
+// CreateSnapshots skips snapshots of a validator at a height that is already recorded,
+// so that snapshots can be recorded again at the same height, e.g. by overlapping runs
+func (q *ValidatorSnapshotMutator) CreateSnapshots(snapshots []models.ValidatorSnapshot) (int64, error) {
+	if len(snapshots) == 0 {
+		return 0, nil
+	}
+
+	res, err := q.session.NewInsert().
+		Model(&snapshots).
+		On("CONFLICT (consensus_address, height) DO NOTHING").
+		Exec(q.ctx)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	return affected, nil
+}
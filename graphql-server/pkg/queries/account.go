@@ -0,0 +1,157 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/oursky/likedao/pkg/models"
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/extra/bunbig"
+)
+
+type IAccountQuery interface {
+	QueryAccountsByAddresses(addresses []string) ([]*models.Account, error)
+	QueryStakesByAddresses(addresses []string) ([]*bunbig.Int, error)
+	QueryTotalStakesByAddressGroups(groups [][]string) ([]*bunbig.Int, error)
+}
+
+type AccountQuery struct {
+	ctx     context.Context
+	session *bun.DB
+}
+
+func NewAccountQuery(ctx context.Context, session *bun.DB) IAccountQuery {
+	return &AccountQuery{ctx: ctx, session: session}
+}
+
+func (q *AccountQuery) QueryAccountsByAddresses(addresses []string) ([]*models.Account, error) {
+	if len(addresses) == 0 {
+		return []*models.Account{}, nil
+	}
+
+	// Unbonding and redelegation entries are kept until they are pruned, only pending ones are returned
+	now := time.Now().UTC()
+
+	var accounts []models.Account
+	err := q.session.NewSelect().
+		Model(&accounts).
+		Relation("Balance").
+		Relation("Delegations", func(query *bun.SelectQuery) *bun.SelectQuery {
+			return query.Order("delegation.height DESC")
+		}).
+		Relation("Delegations.Reward").
+		Relation("UnbondingDelegations", func(query *bun.SelectQuery) *bun.SelectQuery {
+			return query.Where("unbonding_delegation.completion_timestamp > ?", now).Order("unbonding_delegation.completion_timestamp ASC")
+		}).
+		Relation("Redelegations", func(query *bun.SelectQuery) *bun.SelectQuery {
+			return query.Where("redelegation.completion_time > ?", now).Order("redelegation.completion_time ASC")
+		}).
+		Relation("DelegationRewards").
+		Where("account.address IN (?)", bun.In(addresses)).
+		Scan(q.ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result := make([]*models.Account, 0, len(addresses))
+	addressToAccount := make(map[string]models.Account, len(accounts))
+	for _, account := range accounts {
+		addressToAccount[account.Address] = account
+	}
+
+	for _, address := range addresses {
+		account, exists := addressToAccount[address]
+		if exists {
+			result = append(result, &account)
+		} else {
+			result = append(result, nil)
+		}
+	}
+
+	return result, nil
+}
+
+// QueryStakesByAddresses returns the sums of current delegations, which are zero for addresses without delegations
+func (q *AccountQuery) QueryStakesByAddresses(addresses []string) ([]*bunbig.Int, error) {
+	if len(addresses) == 0 {
+		return []*bunbig.Int{}, nil
+	}
+
+	stakes := make([]models.AccountStake, 0, len(addresses))
+	err := q.session.NewSelect().
+		TableExpr("delegation").
+		ColumnExpr("delegation.delegator_address").
+		ColumnExpr("SUM((delegation.amount).amount::numeric) AS stake").
+		Where("delegation.delegator_address IN (?)", bun.In(addresses)).
+		Group("delegation.delegator_address").
+		Scan(q.ctx, &stakes)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	addressToStake := make(map[string]*bunbig.Int, len(stakes))
+	for i := range stakes {
+		addressToStake[stakes[i].DelegatorAddress] = &stakes[i].Stake
+	}
+
+	result := make([]*bunbig.Int, 0, len(addresses))
+	for _, address := range addresses {
+		stake, exists := addressToStake[address]
+		if !exists {
+			stake = bunbig.NewInt()
+		}
+		result = append(result, stake)
+	}
+
+	return result, nil
+}
+
+type addressGroupMember struct {
+	GroupIndex int
+	Address    string
+}
+
+// QueryTotalStakesByAddressGroups sums the stakes of the addresses of each group with a single aggregate query
+func (q *AccountQuery) QueryTotalStakesByAddressGroups(groups [][]string) ([]*bunbig.Int, error) {
+	members := make([]addressGroupMember, 0, len(groups))
+	for i, addresses := range groups {
+		for _, address := range addresses {
+			members = append(members, addressGroupMember{GroupIndex: i, Address: address})
+		}
+	}
+
+	groupToStake := make(map[int]*bunbig.Int, len(groups))
+	if len(members) > 0 {
+		var stakes []struct {
+			GroupIndex int        `bun:"group_index"`
+			Stake      bunbig.Int `bun:"stake"`
+		}
+		err := q.session.NewSelect().
+			With("members", q.session.NewValues(&members)).
+			TableExpr("members").
+			Join("INNER JOIN delegation ON delegation.delegator_address = members.address").
+			ColumnExpr("members.group_index").
+			ColumnExpr("SUM((delegation.amount).amount::numeric) AS stake").
+			Group("members.group_index").
+			Scan(q.ctx, &stakes)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		for i := range stakes {
+			groupToStake[stakes[i].GroupIndex] = &stakes[i].Stake
+		}
+	}
+
+	result := make([]*bunbig.Int, 0, len(groups))
+	for i := range groups {
+		stake, exists := groupToStake[i]
+		if !exists {
+			stake = bunbig.NewInt()
+		}
+		result = append(result, stake)
+	}
+
+	return result, nil
+}
@@ -2,7 +2,9 @@ package queries
 
 import (
 	"context"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/oursky/likedao/pkg/models"
 	"github.com/pkg/errors"
@@ -10,6 +12,9 @@ import (
 )
 
 type IBlockQuery interface {
+	ScopeBlockHeightRange(minHeight *int, maxHeight *int) IBlockQuery
+	ScopeBlockTimeRange(startTime *time.Time, endTime *time.Time) IBlockQuery
+	QueryPaginatedBlocks(pagination Pagination) (*Paginated[models.Block], error)
 	QueryLatestBlock() (*models.Block, error)
 	QueryBlockByHash(hash string) (*models.Block, error)
 	QueryBlocksByHashes(hashes []string) ([]*models.Block, error)
@@ -19,12 +24,89 @@ type IBlockQuery interface {
 type BlockQuery struct {
 	ctx     context.Context
 	session *bun.DB
+
+	scopedMinHeight *int
+	scopedMaxHeight *int
+	scopedStartTime *time.Time
+	scopedEndTime   *time.Time
 }
 
 func NewBlockQuery(ctx context.Context, session *bun.DB) IBlockQuery {
 	return &BlockQuery{ctx: ctx, session: session}
 }
 
+// ScopeBlockHeightRange filters blocks with height within the inclusive range, nil bounds are ignored
+func (q *BlockQuery) ScopeBlockHeightRange(minHeight *int, maxHeight *int) IBlockQuery {
+	var newQuery = *q
+	newQuery.scopedMinHeight = minHeight
+	newQuery.scopedMaxHeight = maxHeight
+	return &newQuery
+}
+
+// ScopeBlockTimeRange filters blocks with timestamp within the inclusive range, nil bounds are ignored
+func (q *BlockQuery) ScopeBlockTimeRange(startTime *time.Time, endTime *time.Time) IBlockQuery {
+	var newQuery = *q
+	newQuery.scopedStartTime = startTime
+	newQuery.scopedEndTime = endTime
+	return &newQuery
+}
+
+func (q *BlockQuery) NewQuery(model interface{}) *bun.SelectQuery {
+	query := q.session.NewSelect().Model(model)
+
+	if q.scopedMinHeight != nil {
+		query = query.Where("block.height >= ?", *q.scopedMinHeight)
+	}
+	if q.scopedMaxHeight != nil {
+		query = query.Where("block.height <= ?", *q.scopedMaxHeight)
+	}
+	// Block timestamps are stored in UTC without time zone
+	if q.scopedStartTime != nil {
+		query = query.Where("block.timestamp >= ?", q.scopedStartTime.UTC())
+	}
+	if q.scopedEndTime != nil {
+		query = query.Where("block.timestamp <= ?", q.scopedEndTime.UTC())
+	}
+
+	return query
+}
+
+// countBlocks counts blocks by the range of their heights, as counting rows scans the whole block table.
+// Heights are consecutive since the earliest indexed block, so the count is only an estimate if blocks are skipped
+func (q *BlockQuery) countBlocks() (int, error) {
+	var minHeight, maxHeight *int
+	err := q.NewQuery((*models.Block)(nil)).
+		ColumnExpr("MIN(block.height), MAX(block.height)").
+		Scan(q.ctx, &minHeight, &maxHeight)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	if minHeight == nil || maxHeight == nil {
+		return 0, nil
+	}
+	return *maxHeight - *minHeight + 1, nil
+}
+
+func (q *BlockQuery) QueryPaginatedBlocks(pagination Pagination) (*Paginated[models.Block], error) {
+	totalCount, err := q.countBlocks()
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []models.Block
+	keys := []SortKey{NewSortKey(models.SortDesc, "block.height")}
+	res, err := Paginate(q.ctx, q.NewQuery(&blocks), &blocks, pagination, keys, func(block models.Block) []string {
+		return []string{strconv.Itoa(block.Height)}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res.PaginationInfo.TotalCount = totalCount
+	return res, nil
+}
+
 func (q *BlockQuery) QueryLatestBlock() (*models.Block, error) {
 	block := new(models.Block)
 	err := q.session.NewSelect().Model(block).Order("timestamp DESC").Limit(1).Scan(q.ctx)
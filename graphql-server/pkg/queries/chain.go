@@ -4,11 +4,17 @@ import (
 	"context"
 
 	"github.com/oursky/likedao/pkg/models"
+	"github.com/pkg/errors"
 	"github.com/uptrace/bun"
 )
 
 type IChainQuery interface {
 	QueryAvergeBlockTime() (*models.AverageBlockTime, error)
+	QueryStakingParams() (*models.StakingParams, error)
+	QuerySlashingParams() (*models.SlashingParams, error)
+	QueryMintParams() (*models.MintParams, error)
+	QueryDistributionParams() (*models.DistributionParams, error)
+	QueryParamValues(keys []models.ParamKey) ([]*string, error)
 }
 
 type ChainQuery struct {
@@ -29,3 +35,96 @@ func (q *ChainQuery) QueryAvergeBlockTime() (*models.AverageBlockTime, error) {
 
 	return averageTime, nil
 }
+
+func (q *ChainQuery) QueryStakingParams() (*models.StakingParams, error) {
+	params := new(models.StakingParams)
+	err := q.session.NewSelect().Model(params).Limit(1).Scan(q.ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return params, nil
+}
+
+func (q *ChainQuery) QuerySlashingParams() (*models.SlashingParams, error) {
+	params := new(models.SlashingParams)
+	err := q.session.NewSelect().Model(params).Limit(1).Scan(q.ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return params, nil
+}
+
+func (q *ChainQuery) QueryMintParams() (*models.MintParams, error) {
+	params := new(models.MintParams)
+	err := q.session.NewSelect().Model(params).Limit(1).Scan(q.ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return params, nil
+}
+
+func (q *ChainQuery) QueryDistributionParams() (*models.DistributionParams, error) {
+	params := new(models.DistributionParams)
+	err := q.session.NewSelect().Model(params).Limit(1).Scan(q.ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return params, nil
+}
+
+// Query the JSON values of params of the params module, params of a subspace are stored by bdjuno as a JSON object
+// keyed by the snake cased param keys, except gov params which are stored as a column for each key.
+// Values of params in unknown subspaces or with unknown keys are nil
+func (q *ChainQuery) QueryParamValues(keys []models.ParamKey) ([]*string, error) {
+	if len(keys) == 0 {
+		return []*string{}, nil
+	}
+
+	subspacesQuery := q.session.NewSelect().
+		Model((*models.GovParams)(nil)).
+		ColumnExpr("'gov' AS subspace").
+		ColumnExpr("jsonb_build_object('depositparams', deposit_params, 'votingparams', voting_params, 'tallyparams', tally_params) AS params").
+		UnionAll(q.session.NewSelect().Model((*models.StakingParams)(nil)).ColumnExpr("'staking' AS subspace").Column("params")).
+		UnionAll(q.session.NewSelect().Model((*models.SlashingParams)(nil)).ColumnExpr("'slashing' AS subspace").Column("params")).
+		UnionAll(q.session.NewSelect().Model((*models.MintParams)(nil)).ColumnExpr("'mint' AS subspace").Column("params")).
+		UnionAll(q.session.NewSelect().Model((*models.DistributionParams)(nil)).ColumnExpr("'distribution' AS subspace").Column("params"))
+
+	var values []struct {
+		Subspace string `bun:"subspace"`
+		Key      string `bun:"key"`
+		Value    string `bun:"value"`
+	}
+	err := q.session.NewSelect().
+		With("keys", q.session.NewValues(&keys)).
+		TableExpr("keys").
+		Join("INNER JOIN (?) AS subspace ON subspace.subspace = keys.subspace", subspacesQuery).
+		Join("CROSS JOIN jsonb_each(subspace.params) AS param").
+		ColumnExpr("keys.subspace, keys.key, param.value::text AS value").
+		Where("lower(replace(param.key, '_', '')) = lower(keys.key)").
+		Scan(q.ctx, &values)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// Reorder query results by order of input keys
+	keyToValue := make(map[models.ParamKey]string, len(values))
+	for _, value := range values {
+		keyToValue[models.ParamKey{Subspace: value.Subspace, Key: value.Key}] = value.Value
+	}
+
+	result := make([]*string, 0, len(keys))
+	for _, key := range keys {
+		value, exists := keyToValue[key]
+		if exists {
+			result = append(result, &value)
+		} else {
+			result = append(result, nil)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,97 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/oursky/likedao/pkg/models"
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+type ICommentQuery interface {
+	ScopeProposal(proposalID int) ICommentQuery
+	ScopeParent(parentID string) ICommentQuery
+	QueryPaginatedComments(pagination Pagination) (*Paginated[models.Comment], error)
+	QueryCommentsByIDs(ids []string) ([]*models.Comment, error)
+}
+
+type CommentQuery struct {
+	ctx     context.Context
+	session *bun.DB
+
+	scopedProposalID *int
+	scopedParentID   *string
+}
+
+func NewCommentQuery(ctx context.Context, session *bun.DB) ICommentQuery {
+	return &CommentQuery{ctx: ctx, session: session}
+}
+
+// ScopeProposal filters top level comments of the proposal
+func (q *CommentQuery) ScopeProposal(proposalID int) ICommentQuery {
+	var newQuery = *q
+	newQuery.scopedProposalID = &proposalID
+	return &newQuery
+}
+
+// ScopeParent filters replies to the comment
+func (q *CommentQuery) ScopeParent(parentID string) ICommentQuery {
+	var newQuery = *q
+	newQuery.scopedParentID = &parentID
+	return &newQuery
+}
+
+func (q *CommentQuery) NewQuery(model interface{}) *bun.SelectQuery {
+	query := q.session.NewSelect().Model(model)
+
+	if q.scopedProposalID != nil {
+		query = query.Where("comment.proposal_id = ?", *q.scopedProposalID).Where("comment.parent_id IS NULL")
+	}
+	if q.scopedParentID != nil {
+		query = query.Where("comment.parent_id = ?", *q.scopedParentID)
+	}
+
+	return query
+}
+
+func (q *CommentQuery) QueryPaginatedComments(pagination Pagination) (*Paginated[models.Comment], error) {
+	totalCount, err := q.NewQuery((*models.Comment)(nil)).Count(q.ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// IDs are ULIDs, which are ordered by their creation time
+	var comments []models.Comment
+	keys := []SortKey{
+		NewSortKey(models.SortAsc, "comment.id"),
+	}
+	res, err := Paginate(q.ctx, q.NewQuery(&comments), &comments, pagination, keys, func(comment models.Comment) []string {
+		return []string{comment.ID}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res.PaginationInfo.TotalCount = totalCount
+	return res, nil
+}
+
+func (q *CommentQuery) QueryCommentsByIDs(ids []string) ([]*models.Comment, error) {
+	comments := make([]models.Comment, 0, len(ids))
+	err := q.session.NewSelect().Model(&comments).Where("id IN (?)", bun.In(ids)).Scan(q.ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	commentByID := make(map[string]*models.Comment, len(comments))
+	for i := range comments {
+		commentByID[comments[i].ID] = &comments[i]
+	}
+
+	result := make([]*models.Comment, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, commentByID[id])
+	}
+
+	return result, nil
+}
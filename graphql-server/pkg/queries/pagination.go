@@ -1,5 +1,18 @@
 package queries
 
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/oursky/likedao/pkg/models"
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
 type PaginationInfo struct {
 	HasNext     bool
 	HasPrevious bool
@@ -7,6 +20,384 @@ type PaginationInfo struct {
 }
 
 type Paginated[T any] struct {
-	Items          []T
+	Items []T
+	// Cursor of each item at the same index
+	Cursors        []models.Cursor
 	PaginationInfo PaginationInfo
 }
+
+// Pagination holds relay connection arguments, see https://relay.dev/graphql/connections.htm#sec-Arguments
+type Pagination struct {
+	First  *int
+	After  models.Cursor
+	Last   *int
+	Before models.Cursor
+	// Number of items to skip from the start, kept for clients paginating by page number
+	Offset int
+}
+
+func NewPagination(first *int, after models.Cursor, last *int, before models.Cursor) (Pagination, error) {
+	if (first == nil) == (last == nil) {
+		return Pagination{}, errors.New("exactly one of first and last must be provided")
+	}
+	if first != nil && *first < 0 {
+		return Pagination{}, errors.New("first must not be negative")
+	}
+	if last != nil && *last < 0 {
+		return Pagination{}, errors.New("last must not be negative")
+	}
+
+	return Pagination{First: first, After: after, Last: last, Before: before}, nil
+}
+
+// NewOffsetPagination is NewPagination with an optional offset, which can only be used with first and without cursors.
+// Offset is only for lists navigated by page number, as the skipped rows are still scanned
+func NewOffsetPagination(first *int, after models.Cursor, last *int, before models.Cursor, offset *int) (Pagination, error) {
+	pagination, err := NewPagination(first, after, last, before)
+	if err != nil || offset == nil {
+		return pagination, err
+	}
+
+	if *offset < 0 {
+		return Pagination{}, errors.New("offset must not be negative")
+	}
+	if first == nil || after != nil || before != nil {
+		return Pagination{}, errors.New("offset can only be used with first and without cursors")
+	}
+
+	pagination.Offset = *offset
+	return pagination, nil
+}
+
+// IsBackward returns true when paginating from the end with last and before
+func (p Pagination) IsBackward() bool {
+	return p.Last != nil
+}
+
+func (p Pagination) limit() int {
+	if p.IsBackward() {
+		return *p.Last
+	}
+	return *p.First
+}
+
+// withLimit returns pagination in the same direction with the given limit and without cursors
+func (p Pagination) withLimit(limit int) Pagination {
+	if p.IsBackward() {
+		return Pagination{Last: &limit}
+	}
+	return Pagination{First: &limit}
+}
+
+// SortKey is an expression results are sorted by. Rows are compared by their keys when paginating,
+// so a key must not be null and the keys of a query together must identify a row
+type SortKey struct {
+	Expr string
+	Args []interface{}
+	Desc bool
+}
+
+func NewSortKey(sort models.Sort, expr string, args ...interface{}) SortKey {
+	return SortKey{Expr: expr, Args: args, Desc: sort == models.SortDesc}
+}
+
+// Paginate fetches a page of results of query with keyset pagination, which does not need to scan skipped rows.
+// query must be created with items as its model and must not be ordered or limited,
+// keyValues returns the values of keys of an item as they would be compared in SQL
+func Paginate[T any](
+	ctx context.Context,
+	query *bun.SelectQuery,
+	items *[]T,
+	pagination Pagination,
+	keys []SortKey,
+	keyValues func(item T) []string,
+) (*Paginated[T], error) {
+	if pagination.After != nil {
+		if len(pagination.After) != len(keys) {
+			return nil, ErrInvalidCursor
+		}
+		query = applyKeyset(query, keys, pagination.After, true)
+	}
+	if pagination.Before != nil {
+		if len(pagination.Before) != len(keys) {
+			return nil, ErrInvalidCursor
+		}
+		query = applyKeyset(query, keys, pagination.Before, false)
+	}
+
+	// Paginating backward reads rows in reverse order from the end
+	backward := pagination.IsBackward()
+	for _, key := range keys {
+		direction := "ASC"
+		if key.Desc != backward {
+			direction = "DESC"
+		}
+		query = query.OrderExpr(fmt.Sprintf("%s %s", key.Expr, direction), key.Args...)
+	}
+
+	limit := pagination.limit()
+	if pagination.Offset > 0 {
+		query = query.Offset(pagination.Offset)
+	}
+	if err := query.Limit(limit + 1).Scan(ctx); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result := *items
+	hasMore := len(result) > limit
+	if hasMore {
+		result = result[:limit]
+	}
+	if backward {
+		for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+			result[i], result[j] = result[j], result[i]
+		}
+	}
+
+	cursors := make([]models.Cursor, 0, len(result))
+	for _, item := range result {
+		cursors = append(cursors, models.Cursor(keyValues(item)))
+	}
+
+	paginationInfo := PaginationInfo{
+		HasNext:     hasMore,
+		HasPrevious: pagination.After != nil || pagination.Offset > 0,
+	}
+	if backward {
+		paginationInfo = PaginationInfo{
+			HasNext:     pagination.Before != nil,
+			HasPrevious: hasMore,
+		}
+	}
+
+	return &Paginated[T]{
+		Items:          result,
+		Cursors:        cursors,
+		PaginationInfo: paginationInfo,
+	}, nil
+}
+
+// PaginateItems paginates items already loaded in the order of the connection,
+// a cursor must have the key values of one of the items
+func PaginateItems[T any](items []T, pagination Pagination, keyValues func(item T) []string) (*Paginated[T], error) {
+	start, end := pagination.Offset, len(items)
+	if start > end {
+		start = end
+	}
+	if pagination.After != nil {
+		index := indexOfCursor(items, pagination.After, keyValues)
+		if index < 0 {
+			return nil, ErrInvalidCursor
+		}
+		start = index + 1
+	}
+	if pagination.Before != nil {
+		index := indexOfCursor(items, pagination.Before, keyValues)
+		if index < 0 {
+			return nil, ErrInvalidCursor
+		}
+		end = index
+	}
+	if end < start {
+		end = start
+	}
+
+	result := items[start:end]
+	limit := pagination.limit()
+	hasMore := len(result) > limit
+	if hasMore {
+		if pagination.IsBackward() {
+			result = result[len(result)-limit:]
+		} else {
+			result = result[:limit]
+		}
+	}
+
+	cursors := make([]models.Cursor, 0, len(result))
+	for _, item := range result {
+		cursors = append(cursors, models.Cursor(keyValues(item)))
+	}
+
+	paginationInfo := PaginationInfo{
+		HasNext:     hasMore,
+		HasPrevious: pagination.After != nil || pagination.Offset > 0,
+		TotalCount:  len(items),
+	}
+	if pagination.IsBackward() {
+		paginationInfo.HasNext = pagination.Before != nil
+		paginationInfo.HasPrevious = hasMore
+	}
+
+	return &Paginated[T]{
+		Items:          result,
+		Cursors:        cursors,
+		PaginationInfo: paginationInfo,
+	}, nil
+}
+
+func indexOfCursor[T any](items []T, cursor models.Cursor, keyValues func(item T) []string) int {
+	for i, item := range items {
+		values := keyValues(item)
+		if len(values) != len(cursor) {
+			continue
+		}
+
+		matched := true
+		for j, value := range values {
+			matched = matched && value == cursor[j]
+		}
+		if matched {
+			return i
+		}
+	}
+	return -1
+}
+
+// applyKeyset filters query to rows strictly after or before the row with the given key values
+func applyKeyset(query *bun.SelectQuery, keys []SortKey, values []string, after bool) *bun.SelectQuery {
+	operators := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key.Desc == after {
+			operators = append(operators, "<")
+		} else {
+			operators = append(operators, ">")
+		}
+	}
+
+	// Row value comparison can make use of a multi-column index when all keys are in the same direction
+	sameDirection := true
+	for _, operator := range operators {
+		sameDirection = sameDirection && operator == operators[0]
+	}
+	if sameDirection {
+		exprs := make([]string, 0, len(keys))
+		placeholders := make([]string, 0, len(keys))
+		args := make([]interface{}, 0)
+		for _, key := range keys {
+			exprs = append(exprs, key.Expr)
+			placeholders = append(placeholders, "?")
+			args = append(args, key.Args...)
+		}
+		for _, value := range values {
+			args = append(args, value)
+		}
+		return query.Where(
+			fmt.Sprintf("(%s) %s (%s)", strings.Join(exprs, ", "), operators[0], strings.Join(placeholders, ", ")),
+			args...,
+		)
+	}
+
+	// (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ...
+	return query.WhereGroup(" AND ", func(query *bun.SelectQuery) *bun.SelectQuery {
+		for i := range keys {
+			conditions := make([]string, 0, i+1)
+			args := make([]interface{}, 0)
+			for j := 0; j < i; j++ {
+				conditions = append(conditions, fmt.Sprintf("%s = ?", keys[j].Expr))
+				args = append(append(args, keys[j].Args...), values[j])
+			}
+			conditions = append(conditions, fmt.Sprintf("%s %s ?", keys[i].Expr, operators[i]))
+			args = append(append(args, keys[i].Args...), values[i])
+
+			query = query.WhereOr(strings.Join(conditions, " AND "), args...)
+		}
+		return query
+	})
+}
+
+// Section fetches a page of one of the consecutive parts of a connection
+type Section[T any] func(pagination Pagination) (*Paginated[T], error)
+
+// PaginateSections paginates the concatenation of sections, the cursor of an item is prefixed with the index of its section.
+// Sections outside of the page are still queried with an empty page for their total counts.
+// An offset is carried over to the following sections after skipping all items of a section
+func PaginateSections[T any](pagination Pagination, sections ...Section[T]) (*Paginated[T], error) {
+	afterSection, after, err := splitSectionCursor(pagination.After, len(sections), 0)
+	if err != nil {
+		return nil, err
+	}
+	beforeSection, before, err := splitSectionCursor(pagination.Before, len(sections), len(sections)-1)
+	if err != nil {
+		return nil, err
+	}
+
+	backward := pagination.IsBackward()
+	limit := pagination.limit()
+	result := &Paginated[T]{
+		Items:   make([]T, 0, limit),
+		Cursors: make([]models.Cursor, 0, limit),
+	}
+
+	hasMore := false
+	offset := pagination.Offset
+	for n := range sections {
+		// Sections are visited in the direction of pagination
+		i := n
+		if backward {
+			i = len(sections) - 1 - n
+		}
+
+		inPage := !hasMore && i >= afterSection && i <= beforeSection
+		sectionPagination := pagination.withLimit(0)
+		if inPage {
+			sectionPagination = pagination.withLimit(limit - len(result.Items))
+			if i == afterSection {
+				sectionPagination.After = after
+			}
+			if i == beforeSection {
+				sectionPagination.Before = before
+			}
+			sectionPagination.Offset = offset
+		}
+
+		page, err := sections[i](sectionPagination)
+		if err != nil {
+			return nil, err
+		}
+		result.PaginationInfo.TotalCount += page.PaginationInfo.TotalCount
+		if !inPage {
+			continue
+		}
+		offset -= page.PaginationInfo.TotalCount
+		if offset < 0 {
+			offset = 0
+		}
+
+		cursors := make([]models.Cursor, 0, len(page.Cursors))
+		for _, cursor := range page.Cursors {
+			cursors = append(cursors, append(models.Cursor{strconv.Itoa(i)}, cursor...))
+		}
+
+		if backward {
+			result.Items = append(page.Items, result.Items...)
+			result.Cursors = append(cursors, result.Cursors...)
+			hasMore = page.PaginationInfo.HasPrevious
+		} else {
+			result.Items = append(result.Items, page.Items...)
+			result.Cursors = append(result.Cursors, cursors...)
+			hasMore = page.PaginationInfo.HasNext
+		}
+	}
+
+	result.PaginationInfo.HasNext = hasMore
+	result.PaginationInfo.HasPrevious = pagination.After != nil || pagination.Offset > 0
+	if backward {
+		result.PaginationInfo.HasNext = pagination.Before != nil
+		result.PaginationInfo.HasPrevious = hasMore
+	}
+
+	return result, nil
+}
+
+func splitSectionCursor(cursor models.Cursor, numOfSections int, defaultSection int) (int, models.Cursor, error) {
+	if cursor == nil {
+		return defaultSection, nil, nil
+	}
+
+	section, err := strconv.Atoi(cursor[0])
+	if err != nil || section < 0 || section >= numOfSections || len(cursor) < 2 {
+		return 0, nil, ErrInvalidCursor
+	}
+
+	return section, cursor[1:], nil
+}
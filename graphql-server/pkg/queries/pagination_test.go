@@ -0,0 +1,483 @@
+package queries_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/oursky/likedao/pkg/models"
+	"github.com/oursky/likedao/pkg/queries"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+)
+
+// stubConnector answers every query with the given rows and records the executed SQL
+type stubConnector struct {
+	columns []string
+	rows    [][]driver.Value
+	queries []string
+}
+
+func (c *stubConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return &stubConn{connector: c}, nil
+}
+
+func (c *stubConnector) Driver() driver.Driver {
+	return nil
+}
+
+type stubConn struct {
+	connector *stubConnector
+}
+
+func (c *stubConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *stubConn) Close() error {
+	return nil
+}
+
+func (c *stubConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *stubConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.connector.queries = append(c.connector.queries, query)
+	return &stubRows{columns: c.connector.columns, rows: c.connector.rows}, nil
+}
+
+type stubRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *stubRows) Columns() []string {
+	return r.columns
+}
+
+func (r *stubRows) Close() error {
+	return nil
+}
+
+func (r *stubRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+type item struct {
+	bun.BaseModel `bun:"table:item"`
+
+	Group int `bun:"group"`
+	ID    int `bun:"id"`
+}
+
+func itemKeyValues(item item) []string {
+	return []string{strconv.Itoa(item.Group), strconv.Itoa(item.ID)}
+}
+
+func newStubDB(items ...item) (*bun.DB, *stubConnector) {
+	connector := &stubConnector{columns: []string{"group", "id"}}
+	for _, item := range items {
+		connector.rows = append(connector.rows, []driver.Value{int64(item.Group), int64(item.ID)})
+	}
+	return bun.NewDB(sql.OpenDB(connector), pgdialect.New()), connector
+}
+
+func intPtr(value int) *int {
+	return &value
+}
+
+func itemIDs(items []item) []int {
+	ids := make([]int, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	return ids
+}
+
+func equalInts(a []int, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func Test_NewPagination(t *testing.T) {
+	testCases := []struct {
+		name   string
+		first  *int
+		after  models.Cursor
+		last   *int
+		before models.Cursor
+		offset *int
+		valid  bool
+	}{
+		{"First", intPtr(10), nil, nil, nil, nil, true},
+		{"Last with before", nil, nil, intPtr(10), models.Cursor{"1"}, nil, true},
+		{"First with offset", intPtr(10), nil, nil, nil, intPtr(20), true},
+		{"Neither first nor last", nil, nil, nil, nil, nil, false},
+		{"Both first and last", intPtr(10), nil, intPtr(10), nil, nil, false},
+		{"Negative first", intPtr(-1), nil, nil, nil, nil, false},
+		{"Negative last", nil, nil, intPtr(-1), nil, nil, false},
+		{"Negative offset", intPtr(10), nil, nil, nil, intPtr(-1), false},
+		{"Offset with last", nil, nil, intPtr(10), nil, intPtr(20), false},
+		{"Offset with after", intPtr(10), models.Cursor{"1"}, nil, nil, intPtr(20), false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := queries.NewOffsetPagination(testCase.first, testCase.after, testCase.last, testCase.before, testCase.offset)
+			if testCase.valid && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			if !testCase.valid && err == nil {
+				t.Errorf("expected error, got no error")
+			}
+		})
+	}
+}
+
+func Test_Paginate(t *testing.T) {
+	ascKeys := []queries.SortKey{
+		queries.NewSortKey(models.SortAsc, "item.group"),
+		queries.NewSortKey(models.SortAsc, "item.id"),
+	}
+	mixedKeys := []queries.SortKey{
+		queries.NewSortKey(models.SortDesc, "item.group"),
+		queries.NewSortKey(models.SortAsc, "item.id"),
+	}
+
+	testCases := []struct {
+		name       string
+		pagination queries.Pagination
+		keys       []queries.SortKey
+		// Rows returned by the database, in the order requested by the query
+		rows        []item
+		sql         []string
+		ids         []int
+		hasNext     bool
+		hasPrevious bool
+	}{
+		{
+			name:        "First page",
+			pagination:  queries.Pagination{First: intPtr(2)},
+			keys:        ascKeys,
+			rows:        []item{{Group: 1, ID: 1}, {Group: 1, ID: 2}, {Group: 1, ID: 3}},
+			sql:         []string{`ORDER BY item.group ASC, item.id ASC LIMIT 3`},
+			ids:         []int{1, 2},
+			hasNext:     true,
+			hasPrevious: false,
+		},
+		{
+			name:        "First after a cursor tied on the first key",
+			pagination:  queries.Pagination{First: intPtr(2), After: models.Cursor{"1", "2"}},
+			keys:        ascKeys,
+			rows:        []item{{Group: 1, ID: 3}, {Group: 2, ID: 4}},
+			sql:         []string{`WHERE ((item.group, item.id) > ('1', '2'))`, `ORDER BY item.group ASC, item.id ASC LIMIT 3`},
+			ids:         []int{3, 4},
+			hasNext:     false,
+			hasPrevious: true,
+		},
+		{
+			name:        "First after a cursor with keys in mixed directions",
+			pagination:  queries.Pagination{First: intPtr(2), After: models.Cursor{"2", "4"}},
+			keys:        mixedKeys,
+			rows:        []item{{Group: 2, ID: 5}, {Group: 1, ID: 1}, {Group: 1, ID: 2}},
+			sql:         []string{`WHERE ((item.group < '2') OR (item.group = '2' AND item.id > '4'))`, `ORDER BY item.group DESC, item.id ASC LIMIT 3`},
+			ids:         []int{5, 1},
+			hasNext:     true,
+			hasPrevious: true,
+		},
+		{
+			name:        "Last page",
+			pagination:  queries.Pagination{Last: intPtr(2)},
+			keys:        ascKeys,
+			rows:        []item{{Group: 2, ID: 4}, {Group: 1, ID: 3}, {Group: 1, ID: 2}},
+			sql:         []string{`ORDER BY item.group DESC, item.id DESC LIMIT 3`},
+			ids:         []int{3, 4},
+			hasNext:     false,
+			hasPrevious: true,
+		},
+		{
+			name:        "Last before a cursor tied on the first key",
+			pagination:  queries.Pagination{Last: intPtr(2), Before: models.Cursor{"1", "3"}},
+			keys:        ascKeys,
+			rows:        []item{{Group: 1, ID: 2}, {Group: 1, ID: 1}},
+			sql:         []string{`WHERE ((item.group, item.id) < ('1', '3'))`, `ORDER BY item.group DESC, item.id DESC LIMIT 3`},
+			ids:         []int{1, 2},
+			hasNext:     true,
+			hasPrevious: false,
+		},
+		{
+			name:        "Last before a cursor with keys in mixed directions",
+			pagination:  queries.Pagination{Last: intPtr(1), Before: models.Cursor{"1", "1"}},
+			keys:        mixedKeys,
+			rows:        []item{{Group: 2, ID: 5}, {Group: 2, ID: 4}},
+			sql:         []string{`WHERE ((item.group > '1') OR (item.group = '1' AND item.id < '1'))`, `ORDER BY item.group ASC, item.id DESC LIMIT 2`},
+			ids:         []int{5},
+			hasNext:     true,
+			hasPrevious: true,
+		},
+		{
+			name:        "First with offset",
+			pagination:  queries.Pagination{First: intPtr(2), Offset: 2},
+			keys:        ascKeys,
+			rows:        []item{{Group: 1, ID: 3}},
+			sql:         []string{`ORDER BY item.group ASC, item.id ASC LIMIT 3 OFFSET 2`},
+			ids:         []int{3},
+			hasNext:     false,
+			hasPrevious: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			db, connector := newStubDB(testCase.rows...)
+
+			var items []item
+			res, err := queries.Paginate(context.Background(), db.NewSelect().Model(&items), &items, testCase.pagination, testCase.keys, itemKeyValues)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if len(connector.queries) != 1 {
+				t.Fatalf("expected 1 query, got %d", len(connector.queries))
+			}
+			for _, sql := range testCase.sql {
+				if !strings.Contains(connector.queries[0], sql) {
+					t.Errorf("expected query to contain %s, got %s", sql, connector.queries[0])
+				}
+			}
+
+			if ids := itemIDs(res.Items); !equalInts(ids, testCase.ids) {
+				t.Errorf("expected items %v, got %v", testCase.ids, ids)
+			}
+			for i, item := range res.Items {
+				if res.Cursors[i].String() != models.Cursor(itemKeyValues(item)).String() {
+					t.Errorf("expected cursor %v of item %d, got %v", itemKeyValues(item), item.ID, res.Cursors[i])
+				}
+			}
+			if res.PaginationInfo.HasNext != testCase.hasNext {
+				t.Errorf("expected has next %t, got %t", testCase.hasNext, res.PaginationInfo.HasNext)
+			}
+			if res.PaginationInfo.HasPrevious != testCase.hasPrevious {
+				t.Errorf("expected has previous %t, got %t", testCase.hasPrevious, res.PaginationInfo.HasPrevious)
+			}
+		})
+	}
+}
+
+func Test_PaginateInvalidCursor(t *testing.T) {
+	keys := []queries.SortKey{
+		queries.NewSortKey(models.SortAsc, "item.group"),
+		queries.NewSortKey(models.SortAsc, "item.id"),
+	}
+
+	testCases := []struct {
+		name       string
+		pagination queries.Pagination
+	}{
+		{"After with fewer values than keys", queries.Pagination{First: intPtr(1), After: models.Cursor{"1"}}},
+		{"Before with more values than keys", queries.Pagination{Last: intPtr(1), Before: models.Cursor{"1", "2", "3"}}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			db, connector := newStubDB()
+
+			var items []item
+			_, err := queries.Paginate(context.Background(), db.NewSelect().Model(&items), &items, testCase.pagination, keys, itemKeyValues)
+			if !errors.Is(err, queries.ErrInvalidCursor) {
+				t.Errorf("expected %v, got %v", queries.ErrInvalidCursor, err)
+			}
+			if len(connector.queries) != 0 {
+				t.Errorf("expected no query, got %v", connector.queries)
+			}
+		})
+	}
+}
+
+func Test_PaginateItems(t *testing.T) {
+	// Items with ties on group, so cursors must carry all key values
+	items := []item{{Group: 1, ID: 1}, {Group: 1, ID: 2}, {Group: 1, ID: 3}, {Group: 2, ID: 4}, {Group: 2, ID: 5}}
+
+	testCases := []struct {
+		name        string
+		pagination  queries.Pagination
+		ids         []int
+		hasNext     bool
+		hasPrevious bool
+	}{
+		{"First", queries.Pagination{First: intPtr(2)}, []int{1, 2}, true, false},
+		{"First after a tie", queries.Pagination{First: intPtr(2), After: models.Cursor{"1", "2"}}, []int{3, 4}, true, true},
+		{"First after to the end", queries.Pagination{First: intPtr(10), After: models.Cursor{"2", "4"}}, []int{5}, false, true},
+		{"First after the last item", queries.Pagination{First: intPtr(2), After: models.Cursor{"2", "5"}}, []int{}, false, true},
+		{"Last", queries.Pagination{Last: intPtr(2)}, []int{4, 5}, false, true},
+		{"Last before a tie", queries.Pagination{Last: intPtr(2), Before: models.Cursor{"2", "5"}}, []int{3, 4}, true, true},
+		{"Last before to the start", queries.Pagination{Last: intPtr(5), Before: models.Cursor{"1", "2"}}, []int{1}, true, false},
+		{"Offset", queries.Pagination{First: intPtr(2), Offset: 2}, []int{3, 4}, true, true},
+		{"Offset beyond items", queries.Pagination{First: intPtr(2), Offset: 10}, []int{}, false, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			res, err := queries.PaginateItems(items, testCase.pagination, itemKeyValues)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if ids := itemIDs(res.Items); !equalInts(ids, testCase.ids) {
+				t.Errorf("expected items %v, got %v", testCase.ids, ids)
+			}
+			if len(res.Cursors) != len(res.Items) {
+				t.Fatalf("expected %d cursors, got %d", len(res.Items), len(res.Cursors))
+			}
+			for i, resItem := range res.Items {
+				if expected := models.Cursor(itemKeyValues(resItem)); res.Cursors[i].String() != expected.String() {
+					t.Errorf("expected cursor %v, got %v", expected, res.Cursors[i])
+				}
+			}
+			if res.PaginationInfo.TotalCount != len(items) {
+				t.Errorf("expected total count %d, got %d", len(items), res.PaginationInfo.TotalCount)
+			}
+			if res.PaginationInfo.HasNext != testCase.hasNext {
+				t.Errorf("expected has next %t, got %t", testCase.hasNext, res.PaginationInfo.HasNext)
+			}
+			if res.PaginationInfo.HasPrevious != testCase.hasPrevious {
+				t.Errorf("expected has previous %t, got %t", testCase.hasPrevious, res.PaginationInfo.HasPrevious)
+			}
+		})
+	}
+
+	t.Run("Invalid cursor", func(t *testing.T) {
+		paginations := []queries.Pagination{
+			{First: intPtr(1), After: models.Cursor{"1", "9"}},
+			{First: intPtr(1), After: models.Cursor{"1"}},
+			{Last: intPtr(1), Before: models.Cursor{"2", "4", "1"}},
+		}
+		for _, pagination := range paginations {
+			_, err := queries.PaginateItems(items, pagination, itemKeyValues)
+			if !errors.Is(err, queries.ErrInvalidCursor) {
+				t.Errorf("expected %v for %+v, got %v", queries.ErrInvalidCursor, pagination, err)
+			}
+		}
+	})
+}
+
+// staticSection pages items held in memory like a database would, with ids as cursors
+func staticSection(items []item) queries.Section[item] {
+	return func(pagination queries.Pagination) (*queries.Paginated[item], error) {
+		return queries.PaginateItems(items, pagination, func(item item) []string {
+			return []string{strconv.Itoa(item.ID)}
+		})
+	}
+}
+
+func Test_PaginateSections(t *testing.T) {
+	sections := []queries.Section[item]{
+		staticSection([]item{{ID: 1}, {ID: 2}}),
+		staticSection([]item{{ID: 3}, {ID: 4}, {ID: 5}}),
+	}
+
+	testCases := []struct {
+		name        string
+		pagination  queries.Pagination
+		ids         []int
+		cursors     []models.Cursor
+		hasNext     bool
+		hasPrevious bool
+	}{
+		{
+			name:        "First across sections",
+			pagination:  queries.Pagination{First: intPtr(3)},
+			ids:         []int{1, 2, 3},
+			cursors:     []models.Cursor{{"0", "1"}, {"0", "2"}, {"1", "3"}},
+			hasNext:     true,
+			hasPrevious: false,
+		},
+		{
+			name:        "First after a cursor in the first section",
+			pagination:  queries.Pagination{First: intPtr(2), After: models.Cursor{"0", "2"}},
+			ids:         []int{3, 4},
+			cursors:     []models.Cursor{{"1", "3"}, {"1", "4"}},
+			hasNext:     true,
+			hasPrevious: true,
+		},
+		{
+			name:        "Last across sections",
+			pagination:  queries.Pagination{Last: intPtr(4)},
+			ids:         []int{2, 3, 4, 5},
+			cursors:     []models.Cursor{{"0", "2"}, {"1", "3"}, {"1", "4"}, {"1", "5"}},
+			hasNext:     false,
+			hasPrevious: true,
+		},
+		{
+			name:        "Last before a cursor in the second section",
+			pagination:  queries.Pagination{Last: intPtr(2), Before: models.Cursor{"1", "4"}},
+			ids:         []int{2, 3},
+			cursors:     []models.Cursor{{"0", "2"}, {"1", "3"}},
+			hasNext:     true,
+			hasPrevious: true,
+		},
+		{
+			name:        "Offset skipping the first section",
+			pagination:  queries.Pagination{First: intPtr(2), Offset: 3},
+			ids:         []int{4, 5},
+			cursors:     []models.Cursor{{"1", "4"}, {"1", "5"}},
+			hasNext:     false,
+			hasPrevious: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			res, err := queries.PaginateSections(testCase.pagination, sections...)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if ids := itemIDs(res.Items); !equalInts(ids, testCase.ids) {
+				t.Errorf("expected items %v, got %v", testCase.ids, ids)
+			}
+			for i := range testCase.cursors {
+				if i >= len(res.Cursors) || res.Cursors[i].String() != testCase.cursors[i].String() {
+					t.Errorf("expected cursors %v, got %v", testCase.cursors, res.Cursors)
+					break
+				}
+			}
+			if res.PaginationInfo.TotalCount != 5 {
+				t.Errorf("expected total count 5, got %d", res.PaginationInfo.TotalCount)
+			}
+			if res.PaginationInfo.HasNext != testCase.hasNext {
+				t.Errorf("expected has next %t, got %t", testCase.hasNext, res.PaginationInfo.HasNext)
+			}
+			if res.PaginationInfo.HasPrevious != testCase.hasPrevious {
+				t.Errorf("expected has previous %t, got %t", testCase.hasPrevious, res.PaginationInfo.HasPrevious)
+			}
+		})
+	}
+
+	t.Run("Invalid section cursor", func(t *testing.T) {
+		for _, cursor := range []models.Cursor{{"2", "1"}, {"a", "1"}, {"0"}} {
+			_, err := queries.PaginateSections(queries.Pagination{First: intPtr(1), After: cursor}, sections...)
+			if !errors.Is(err, queries.ErrInvalidCursor) {
+				t.Errorf("expected %v for cursor %v, got %v", queries.ErrInvalidCursor, cursor, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/oursky/likedao/pkg/models"
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+type IPostQuery interface {
+	ScopeProposal(proposalID int) IPostQuery
+	QueryPaginatedPosts(pagination Pagination) (*Paginated[models.Post], error)
+	QueryPostsByIDs(ids []string) ([]*models.Post, error)
+	QueryPostsByISCNIDs(iscnIDs []string) ([]*models.Post, error)
+}
+
+type PostQuery struct {
+	ctx     context.Context
+	session *bun.DB
+
+	scopedProposalID *int
+}
+
+func NewPostQuery(ctx context.Context, session *bun.DB) IPostQuery {
+	return &PostQuery{ctx: ctx, session: session}
+}
+
+// ScopeProposal filters posts about the proposal
+func (q *PostQuery) ScopeProposal(proposalID int) IPostQuery {
+	var newQuery = *q
+	newQuery.scopedProposalID = &proposalID
+	return &newQuery
+}
+
+func (q *PostQuery) NewQuery(model interface{}) *bun.SelectQuery {
+	query := q.session.NewSelect().Model(model)
+
+	if q.scopedProposalID != nil {
+		query = query.Where("post.proposal_id = ?", *q.scopedProposalID)
+	}
+
+	return query
+}
+
+func (q *PostQuery) QueryPaginatedPosts(pagination Pagination) (*Paginated[models.Post], error) {
+	totalCount, err := q.NewQuery((*models.Post)(nil)).Count(q.ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var posts []models.Post
+	keys := []SortKey{
+		NewSortKey(models.SortAsc, "post.record_timestamp"),
+		NewSortKey(models.SortAsc, "post.id"),
+	}
+	res, err := Paginate(q.ctx, q.NewQuery(&posts), &posts, pagination, keys, func(post models.Post) []string {
+		return []string{post.RecordTimestamp.UTC().Format(time.RFC3339Nano), post.ID}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res.PaginationInfo.TotalCount = totalCount
+	return res, nil
+}
+
+func (q *PostQuery) QueryPostsByIDs(ids []string) ([]*models.Post, error) {
+	return q.queryPostsByColumn("id", ids, func(post *models.Post) string { return post.ID })
+}
+
+func (q *PostQuery) QueryPostsByISCNIDs(iscnIDs []string) ([]*models.Post, error) {
+	return q.queryPostsByColumn("iscn_id", iscnIDs, func(post *models.Post) string { return post.ISCNID })
+}
+
+func (q *PostQuery) queryPostsByColumn(column string, values []string, key func(post *models.Post) string) ([]*models.Post, error) {
+	posts := make([]models.Post, 0, len(values))
+	err := q.session.NewSelect().Model(&posts).Where("? IN (?)", bun.Ident(column), bun.In(values)).Scan(q.ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	postByKey := make(map[string]*models.Post, len(posts))
+	for i := range posts {
+		postByKey[key(&posts[i])] = &posts[i]
+	}
+
+	result := make([]*models.Post, 0, len(values))
+	for _, value := range values {
+		result = append(result, postByKey[value])
+	}
+
+	return result, nil
+}
@@ -3,7 +3,12 @@ package queries
 import (
 	"context"
 	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
 
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/forbole/bdjuno/database/types"
 	"github.com/oursky/likedao/pkg/config"
 	servererrors "github.com/oursky/likedao/pkg/errors"
@@ -15,17 +20,21 @@ import (
 type IProposalQuery interface {
 	ScopeProposalStatus(filter models.ProposalStatus) IProposalQuery
 	ScopeProposalAddress(filter *models.ProposalAddressFilter) IProposalQuery
-	QueryPaginatedProposalDeposits(proposalID int, first int, after int, orderBy *models.ProposalDepositSort, excludeAddresses []string) (*Paginated[models.ProposalDeposit], error)
-	QueryPaginatedProposalVotes(proposalID int, first int, after int, orderBy *models.ProposalVoteSort, excludeAddresses []string) (*Paginated[models.ProposalVote], error)
+	QueryPaginatedProposalDeposits(proposalID int, pagination Pagination, orderBy *models.ProposalDepositSort, excludeValidators []string) (*Paginated[models.ProposalDeposit], error)
+	QueryPaginatedProposalVotes(proposalID int, pagination Pagination, orderBy *models.ProposalVoteSort, excludeValidators []string) (*Paginated[models.ProposalVote], error)
 	ScopeProposalOrder(order *models.ProposalSort) IProposalQuery
-	QueryPaginatedProposals(first int, after int) (*Paginated[models.Proposal], error)
+	ScopeProposalSearchTerm(searchTerm string) IProposalQuery
+	QueryPaginatedProposals(pagination Pagination) (*Paginated[models.Proposal], error)
 	QueryProposalTallyResults(id []int) ([]*models.ProposalTallyResult, error)
 	QueryProposalByIDs(ids []string) ([]*models.Proposal, error)
 	QueryProposalDepositTotal(id int) ([]types.DbDecCoin, error)
 	QueryTurnoutByProposalIDs(ids []int) ([]*float64, error)
+	QueryProposalStakingPoolSnapshots(ids []int) ([]*models.ProposalStakingPoolSnapshot, error)
 	QueryProposalVotes(keys []models.ProposalVoteKey) ([]*models.ProposalVote, error)
+	QueryProposalVotesAfterHeight(proposalID int, height int64) ([]models.ProposalVote, error)
 	QueryProposalDeposits(keys []models.ProposalDepositKey) ([]*models.ProposalDeposit, error)
 	QueryProposalVoteCountByAddress(address string) (*models.ProposalTallyResult, error)
+	QueryProposalVotingPowerBreakdowns(ids []int) ([]*models.ProposalVotingPowerBreakdown, error)
 }
 
 type ProposalQuery struct {
@@ -36,8 +45,18 @@ type ProposalQuery struct {
 	scopedProposalStatus models.ProposalStatus
 	scopedAddressFilter  *models.ProposalAddressFilter
 	scopedOrder          *models.ProposalSort
+	scopedSearchTerm     string
 }
 
+// Weighted full-text document of a proposal, matches in title rank higher than those in description
+const proposalSearchDocument = `(
+	setweight(to_tsvector('english', proposal.title), 'A') ||
+	setweight(to_tsvector('english', proposal.description), 'B')
+)`
+
+// Full-text rank of a proposal document against a search term, rounded so that it can be compared exactly in cursors
+const proposalSearchRank = "ROUND(ts_rank(?, websearch_to_tsquery('english', ?))::numeric, 6)"
+
 func NewProposalQuery(ctx context.Context, config config.Config, session *bun.DB) IProposalQuery {
 	return &ProposalQuery{ctx: ctx, config: config, session: session}
 }
@@ -46,8 +65,8 @@ func NewProposalQuery(ctx context.Context, config config.Config, session *bun.DB
 // when both status and address filters are applied, results satisfying both filters are returned.
 // when multiple sub-filters in address filter (ie. isDepositor, IsSubmitter, IsVoter), results satisfying
 // any one of the sub-filters are returned
-func (q *ProposalQuery) NewQuery() *bun.SelectQuery {
-	query := q.session.NewSelect().Model((*models.Proposal)(nil))
+func (q *ProposalQuery) NewQuery(model interface{}) *bun.SelectQuery {
+	query := q.session.NewSelect().Model(model)
 
 	if q.scopedProposalStatus != "" {
 		query = query.Where("status = ?", q.scopedProposalStatus)
@@ -78,15 +97,95 @@ func (q *ProposalQuery) NewQuery() *bun.SelectQuery {
 		})
 	}
 
-	if q.scopedOrder != nil {
-		if q.scopedOrder.SubmitTime != nil {
-			query = query.Order(fmt.Sprintf("submit_time %s", q.scopedOrder.SubmitTime))
-		}
+	if q.scopedSearchTerm != "" {
+		// Substring match as fallback for text that is not tokenized by the english parser (e.g. CJK)
+		likePattern := q.searchLikePattern()
+		proposalID, isProposalID := q.searchProposalID()
+
+		query = query.WhereGroup(" AND ", func(query *bun.SelectQuery) *bun.SelectQuery {
+			query = query.
+				WhereOr("? @@ websearch_to_tsquery('english', ?)", bun.Safe(proposalSearchDocument), q.scopedSearchTerm).
+				WhereOr("proposal.title ILIKE ?", likePattern).
+				WhereOr("proposal.description ILIKE ?", likePattern)
+			if isProposalID {
+				query = query.WhereOr("proposal.id = ?", proposalID)
+			}
+			return query
+		})
+
+		// Relevance is selected for sorting and pagination
+		query = query.
+			ColumnExpr("proposal.*").
+			ColumnExpr(proposalSearchRank+" AS search_rank", bun.Safe(proposalSearchDocument), q.scopedSearchTerm).
+			ColumnExpr("proposal.title ILIKE ? AS search_title_match", likePattern)
 	}
 
 	return query
 }
 
+func (q *ProposalQuery) searchLikePattern() string {
+	return fmt.Sprintf("%%%s%%", escapeLikePattern(q.scopedSearchTerm))
+}
+
+// A numeric search term, optionally prefixed by #, refers to a proposal ID
+func (q *ProposalQuery) searchProposalID() (int, bool) {
+	proposalID, err := strconv.Atoi(strings.TrimPrefix(q.scopedSearchTerm, "#"))
+	return proposalID, err == nil && proposalID >= 0
+}
+
+// sortKeys returns the keys proposals are sorted by and a function returning the values of keys of a proposal.
+// Search results are ranked by relevance unless an order is given, in which case relevance only breaks ties
+func (q *ProposalQuery) sortKeys() ([]SortKey, func(proposal models.Proposal) []string) {
+	keys := make([]SortKey, 0)
+	valueFns := make([]func(proposal models.Proposal) string, 0)
+
+	sort := models.SortDesc
+	ordered := q.scopedOrder != nil && q.scopedOrder.SubmitTime != nil
+	if ordered {
+		sort = *q.scopedOrder.SubmitTime
+		keys = append(keys, NewSortKey(sort, "proposal.submit_time"))
+		valueFns = append(valueFns, proposalSubmitTimeKeyValue)
+	}
+
+	// Rank by relevance: exact proposal id match, then full-text rank, then substring match in title
+	if q.scopedSearchTerm != "" {
+		if proposalID, isProposalID := q.searchProposalID(); isProposalID {
+			keys = append(keys, NewSortKey(models.SortDesc, "(proposal.id = ?)", proposalID))
+			valueFns = append(valueFns, func(proposal models.Proposal) string {
+				return strconv.FormatBool(proposal.ID == proposalID)
+			})
+		}
+
+		keys = append(keys,
+			NewSortKey(models.SortDesc, proposalSearchRank, bun.Safe(proposalSearchDocument), q.scopedSearchTerm),
+			NewSortKey(models.SortDesc, "(proposal.title ILIKE ?)", q.searchLikePattern()),
+		)
+		valueFns = append(valueFns,
+			func(proposal models.Proposal) string { return proposal.SearchRank },
+			func(proposal models.Proposal) string { return strconv.FormatBool(proposal.SearchTitleMatch) },
+		)
+	}
+
+	if !ordered {
+		keys = append(keys, NewSortKey(sort, "proposal.submit_time"))
+		valueFns = append(valueFns, proposalSubmitTimeKeyValue)
+	}
+	keys = append(keys, NewSortKey(sort, "proposal.id"))
+	valueFns = append(valueFns, func(proposal models.Proposal) string { return strconv.Itoa(proposal.ID) })
+
+	return keys, func(proposal models.Proposal) []string {
+		values := make([]string, 0, len(valueFns))
+		for _, valueFn := range valueFns {
+			values = append(values, valueFn(proposal))
+		}
+		return values
+	}
+}
+
+func proposalSubmitTimeKeyValue(proposal models.Proposal) string {
+	return proposal.SubmitTime.UTC().Format(time.RFC3339Nano)
+}
+
 func (q *ProposalQuery) ScopeProposalStatus(status models.ProposalStatus) IProposalQuery {
 	var newQuery = *q
 	newQuery.scopedProposalStatus = status
@@ -105,145 +204,234 @@ func (q *ProposalQuery) ScopeProposalOrder(order *models.ProposalSort) IProposal
 	return &newQuery
 }
 
-func (q *ProposalQuery) QueryPaginatedProposals(first int, after int) (*Paginated[models.Proposal], error) {
-	query := q.NewQuery()
+func (q *ProposalQuery) ScopeProposalSearchTerm(searchTerm string) IProposalQuery {
+	var newQuery = *q
+	newQuery.scopedSearchTerm = strings.TrimSpace(searchTerm)
+	return &newQuery
+}
 
-	totalCount, err := q.NewQuery().Count(q.ctx)
+func (q *ProposalQuery) QueryPaginatedProposals(pagination Pagination) (*Paginated[models.Proposal], error) {
+	totalCount, err := q.NewQuery((*models.Proposal)(nil)).Count(q.ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// TODO: Handle search
-
-	query = query.Order("submit_time DESC").Order("id DESC").Limit(first + 1).Offset(after)
-
 	var proposals []models.Proposal
-	if err := query.Scan(q.ctx, &proposals); err != nil {
-		return nil, errors.WithStack(err)
+	keys, keyValues := q.sortKeys()
+	res, err := Paginate(q.ctx, q.NewQuery(&proposals), &proposals, pagination, keys, keyValues)
+	if err != nil {
+		return nil, err
 	}
 
-	hasNextPage := len(proposals) > first
-	hasPreviousPage := after > 0
+	res.PaginationInfo.TotalCount = totalCount
+	return res, nil
+}
 
-	if len(proposals) > first {
-		proposals = proposals[:first]
-	}
+// Self delegation addresses of validators with the given operator addresses
+func (q *ProposalQuery) newValidatorSelfDelegationAddressesQuery(operatorAddresses []string) *bun.SelectQuery {
+	return q.session.NewSelect().
+		Model((*models.ValidatorInfo)(nil)).
+		Column("self_delegate_address").
+		Where("operator_address IN (?)", bun.In(operatorAddresses)).
+		Where("self_delegate_address IS NOT NULL")
+}
 
-	return &Paginated[models.Proposal]{
-		Items: proposals,
-		PaginationInfo: PaginationInfo{
-			HasNext:     hasNextPage,
-			HasPrevious: hasPreviousPage,
-			TotalCount:  totalCount,
-		},
-	}, nil
+// Weighted votes are stored as a row per option, group them into a vote per voter with the options
+// ordered by weight, the option with the largest weight is used as the option of the vote
+func newProposalVoteTableQuery(session *bun.DB) *bun.SelectQuery {
+	return session.NewSelect().
+		TableExpr("proposal_vote").
+		Column("proposal_id", "voter_address").
+		ColumnExpr("MAX(height) AS height").
+		ColumnExpr("(ARRAY_AGG(option ORDER BY weight::numeric DESC, option ASC))[1] AS option").
+		ColumnExpr("JSONB_AGG(JSONB_BUILD_OBJECT('option', option, 'weight', weight) ORDER BY weight::numeric DESC, option ASC) AS options").
+		Group("proposal_id", "voter_address")
+}
 
+// Voting power of a vote in proposal_vote, following the tally logic of the gov module. A voter votes with its delegations,
+// and a validator also votes with delegations to it by delegators who have not voted themselves.
+// Only delegations to validators bonded in the validator status snapshot of the proposal are counted, or to those currently
+// bonded if there is no snapshot. bdjuno only keeps the latest delegations so the voting power is based on them
+func (q *ProposalQuery) newVotingPowerExpr() bun.Safe {
+	bondedValidatorsQuery := q.session.NewSelect().
+		TableExpr("validator_status").
+		Column("validator_status.validator_address").
+		Join("LEFT JOIN proposal_validator_status_snapshot AS status_snapshot ON status_snapshot.validator_address = validator_status.validator_address AND status_snapshot.proposal_id = proposal_vote.proposal_id").
+		Where("COALESCE(status_snapshot.status, validator_status.status) = ?", stakingtypes.Bonded)
+
+	delegationsQuery := q.session.NewSelect().
+		TableExpr("delegation").
+		ColumnExpr("COALESCE(SUM((delegation.amount).amount::numeric), 0)").
+		Where("delegation.delegator_address = proposal_vote.voter_address").
+		Where("delegation.validator_address IN (?)", bondedValidatorsQuery)
+
+	inheritedDelegationsQuery := q.session.NewSelect().
+		TableExpr("delegation").
+		ColumnExpr("COALESCE(SUM((delegation.amount).amount::numeric), 0)").
+		Join("INNER JOIN validator_info ON validator_info.consensus_address = delegation.validator_address").
+		Where("validator_info.self_delegate_address = proposal_vote.voter_address").
+		Where("delegation.validator_address IN (?)", bondedValidatorsQuery).
+		Where("NOT EXISTS (?)", q.session.NewSelect().
+			TableExpr("proposal_vote AS delegator_vote").
+			ColumnExpr("1").
+			Where("delegator_vote.proposal_id = proposal_vote.proposal_id").
+			Where("delegator_vote.voter_address = delegation.delegator_address"))
+
+	return bun.Safe(q.session.Formatter().FormatQuery("((?) + (?))", delegationsQuery, inheritedDelegationsQuery))
 }
 
-func (q *ProposalQuery) NewProposalVotesQuery(proposalID int, excludeAddresses []string) *bun.SelectQuery {
-	query := q.session.NewSelect().Model((*models.ProposalVote)(nil)).Where("proposal_id = ?", proposalID)
+func (q *ProposalQuery) NewProposalVotesQuery(model interface{}, proposalID int, excludeValidators []string) *bun.SelectQuery {
+	query := q.session.NewSelect().
+		Model(model).
+		ModelTableExpr("(?) AS proposal_vote", newProposalVoteTableQuery(q.session)).
+		ColumnExpr("proposal_vote.*").
+		ColumnExpr("? AS voting_power", q.newVotingPowerExpr()).
+		Where("proposal_vote.proposal_id = ?", proposalID)
 
-	if len(excludeAddresses) != 0 {
-		query = query.Where("voter_address NOT IN (?)", bun.In(excludeAddresses))
+	if len(excludeValidators) != 0 {
+		query = query.Where("proposal_vote.voter_address NOT IN (?)", q.newValidatorSelfDelegationAddressesQuery(excludeValidators))
 	}
 
 	return query
 }
-func (q *ProposalQuery) QueryPaginatedProposalVotes(proposalID int, first int, after int, orderBy *models.ProposalVoteSort, excludeAddresses []string) (*Paginated[models.ProposalVote], error) {
-	totalCount, err := q.NewProposalVotesQuery(proposalID, excludeAddresses).Count(q.ctx)
+
+func (q *ProposalQuery) QueryPaginatedProposalVotes(proposalID int, pagination Pagination, orderBy *models.ProposalVoteSort, excludeValidators []string) (*Paginated[models.ProposalVote], error) {
+	totalCount, err := q.NewProposalVotesQuery((*models.ProposalVote)(nil), proposalID, excludeValidators).Count(q.ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	var votes []models.ProposalVote
-	query := q.NewProposalVotesQuery(proposalID, excludeAddresses).
+	query := q.NewProposalVotesQuery(&votes, proposalID, excludeValidators).
 		Relation("ValidatorInfo.Validator.Description")
 
+	var keys []SortKey
+	var keyValues func(vote models.ProposalVote) []string
 	if orderBy.Voter != nil {
-		query = query.Order(fmt.Sprintf("moniker %s", orderBy.Voter)).Order(fmt.Sprintf("voter_address %s", orderBy.Voter))
+		keys = monikerSortKeys(*orderBy.Voter, `"validator_info__validator__description"."moniker"`, "proposal_vote.voter_address")
+		keyValues = func(vote models.ProposalVote) []string {
+			return monikerSortKeyValues(validatorInfoDescription(vote.ValidatorInfo), vote.VoterAddress)
+		}
+	} else if orderBy.VotingPower != nil {
+		keys = []SortKey{
+			NewSortKey(*orderBy.VotingPower, "?", q.newVotingPowerExpr()),
+			NewSortKey(*orderBy.VotingPower, "proposal_vote.voter_address"),
+		}
+		keyValues = func(vote models.ProposalVote) []string {
+			votingPower := "0"
+			if vote.VotingPower != nil {
+				votingPower = vote.VotingPower.String()
+			}
+			return []string{votingPower, vote.VoterAddress}
+		}
 	} else if orderBy.Option != nil {
-		query = query.Order(fmt.Sprintf("option %s", orderBy.Option))
+		keys = []SortKey{
+			NewSortKey(*orderBy.Option, "proposal_vote.option"),
+			NewSortKey(*orderBy.Option, "proposal_vote.voter_address"),
+		}
+		keyValues = func(vote models.ProposalVote) []string {
+			return []string{string(vote.Option), vote.VoterAddress}
+		}
 	} else {
-		query = query.Order("height DESC")
+		keys = []SortKey{
+			NewSortKey(models.SortDesc, "proposal_vote.height"),
+			NewSortKey(models.SortDesc, "proposal_vote.voter_address"),
+		}
+		keyValues = func(vote models.ProposalVote) []string {
+			return []string{strconv.FormatInt(vote.Height, 10), vote.VoterAddress}
+		}
 	}
 
-	query = query.Limit(first + 1).
-		Offset(after)
-
-	err = query.Scan(q.ctx, &votes)
+	res, err := Paginate(q.ctx, query, &votes, pagination, keys, keyValues)
 	if err != nil {
-		return nil, errors.WithStack(err)
-	}
-
-	hasNextPage := len(votes) > first
-	hasPreviousPage := after > 0
-
-	if len(votes) > first {
-		votes = votes[:first]
+		return nil, err
 	}
 
-	return &Paginated[models.ProposalVote]{
-		Items: votes,
-		PaginationInfo: PaginationInfo{
-			HasNext:     hasNextPage,
-			HasPrevious: hasPreviousPage,
-			TotalCount:  totalCount,
-		},
-	}, nil
-
+	res.PaginationInfo.TotalCount = totalCount
+	return res, nil
 }
 
-func (q *ProposalQuery) NewProposalDepositQuery(proposalID int, excludeAddresses []string) *bun.SelectQuery {
-	query := q.session.NewSelect().Model((*models.ProposalDeposit)(nil)).Where("proposal_id = ?", proposalID)
+func (q *ProposalQuery) NewProposalDepositQuery(model interface{}, proposalID int, excludeValidators []string) *bun.SelectQuery {
+	query := q.session.NewSelect().Model(model).Where("proposal_deposit.proposal_id = ?", proposalID)
 
-	if len(excludeAddresses) != 0 {
-		query = query.Where("depositor_address NOT IN (?)", bun.In(excludeAddresses))
+	if len(excludeValidators) != 0 {
+		query = query.Where("COALESCE(proposal_deposit.depositor_address, '') NOT IN (?)", q.newValidatorSelfDelegationAddressesQuery(excludeValidators))
 	}
 
 	return query
 }
-func (q *ProposalQuery) QueryPaginatedProposalDeposits(proposalID int, first int, after int, orderBy *models.ProposalDepositSort, excludeAddresses []string) (*Paginated[models.ProposalDeposit], error) {
-	totalCount, err := q.NewProposalDepositQuery(proposalID, excludeAddresses).Count(q.ctx)
+
+func (q *ProposalQuery) QueryPaginatedProposalDeposits(proposalID int, pagination Pagination, orderBy *models.ProposalDepositSort, excludeValidators []string) (*Paginated[models.ProposalDeposit], error) {
+	totalCount, err := q.NewProposalDepositQuery((*models.ProposalDeposit)(nil), proposalID, excludeValidators).Count(q.ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	var deposits []models.ProposalDeposit
-	query := q.NewProposalDepositQuery(proposalID, excludeAddresses).
+	query := q.NewProposalDepositQuery(&deposits, proposalID, excludeValidators).
 		Relation("ValidatorInfo.Validator.Description")
 
+	// Depositor address and height are null for the initial deposit
+	var keys []SortKey
+	var keyValues func(deposit models.ProposalDeposit) []string
 	if orderBy.Depositor != nil {
-		query = query.Order(fmt.Sprintf("moniker %s", orderBy.Depositor)).Order(fmt.Sprintf("depositor_address %s", orderBy.Depositor))
+		keys = monikerSortKeys(*orderBy.Depositor, `"validator_info__validator__description"."moniker"`, "COALESCE(proposal_deposit.depositor_address, '')")
+		keyValues = func(deposit models.ProposalDeposit) []string {
+			return monikerSortKeyValues(validatorInfoDescription(deposit.ValidatorInfo), deposit.DepositorAddress)
+		}
 	} else if orderBy.Amount != nil {
-		query = query.Order(fmt.Sprintf("amount %s", orderBy.Amount))
+		// Deposits are compared by their amount in chain's coin denom
+		keys = []SortKey{
+			NewSortKey(
+				*orderBy.Amount,
+				"(SELECT COALESCE(SUM((coin).amount::numeric), 0) FROM unnest(proposal_deposit.amount) AS coin WHERE (coin).denom = ?)",
+				q.config.Chain.CoinDenom,
+			),
+			NewSortKey(*orderBy.Amount, "COALESCE(proposal_deposit.depositor_address, '')"),
+		}
+		keyValues = func(deposit models.ProposalDeposit) []string {
+			return []string{sumCoinAmount(deposit.Amount, q.config.Chain.CoinDenom), deposit.DepositorAddress}
+		}
 	} else {
-		query = query.Order("height DESC")
+		keys = []SortKey{
+			NewSortKey(models.SortDesc, "COALESCE(proposal_deposit.height, 0)"),
+			NewSortKey(models.SortDesc, "COALESCE(proposal_deposit.depositor_address, '')"),
+		}
+		keyValues = func(deposit models.ProposalDeposit) []string {
+			return []string{strconv.FormatInt(deposit.Height, 10), deposit.DepositorAddress}
+		}
 	}
 
-	query = query.Limit(first + 1).
-		Offset(after)
-
-	err = query.Scan(q.ctx, &deposits)
+	res, err := Paginate(q.ctx, query, &deposits, pagination, keys, keyValues)
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, err
 	}
 
-	hasNextPage := len(deposits) > first
-	hasPreviousPage := after > 0
+	res.PaginationInfo.TotalCount = totalCount
+	return res, nil
+}
 
-	if len(deposits) > first {
-		deposits = deposits[:first]
+func validatorInfoDescription(info *models.ValidatorInfo) *models.ValidatorDescription {
+	if info == nil || info.Validator == nil {
+		return nil
 	}
+	return info.Validator.Description
+}
 
-	return &Paginated[models.ProposalDeposit]{
-		Items: deposits,
-		PaginationInfo: PaginationInfo{
-			HasNext:     hasNextPage,
-			HasPrevious: hasPreviousPage,
-			TotalCount:  totalCount,
-		},
-	}, nil
-
+// sumCoinAmount returns the exact sum of amounts of coins in denom, coin amounts have at most 18 decimal places
+// like sdk.Dec
+func sumCoinAmount(coins []types.DbDecCoin, denom string) string {
+	sum := new(big.Rat)
+	for _, coin := range coins {
+		if coin.Denom != denom {
+			continue
+		}
+		amount, ok := new(big.Rat).SetString(coin.Amount)
+		if ok {
+			sum.Add(sum, amount)
+		}
+	}
+	return sum.FloatString(18)
 }
 
 func (q *ProposalQuery) QueryProposalTallyResults(ids []int) ([]*models.ProposalTallyResult, error) {
@@ -280,7 +468,7 @@ func (q *ProposalQuery) QueryProposalByIDs(ids []string) ([]*models.Proposal, er
 	}
 
 	proposals := make([]models.Proposal, 0)
-	err := q.NewQuery().Where("id IN (?)", bun.In(ids)).Scan(q.ctx, &proposals)
+	err := q.NewQuery(&proposals).Where("id IN (?)", bun.In(ids)).Scan(q.ctx)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -367,6 +555,39 @@ func (q *ProposalQuery) QueryTurnoutByProposalIDs(ids []int) ([]*float64, error)
 	return result, nil
 }
 
+func (q *ProposalQuery) QueryProposalStakingPoolSnapshots(ids []int) ([]*models.ProposalStakingPoolSnapshot, error) {
+	if len(ids) == 0 {
+		return []*models.ProposalStakingPoolSnapshot{}, nil
+	}
+
+	var snapshots []models.ProposalStakingPoolSnapshot
+	err := q.session.NewSelect().
+		Model(&snapshots).
+		Where("proposal_id IN (?)", bun.In(ids)).
+		Scan(q.ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// Reorder query results by order of input ids
+	result := make([]*models.ProposalStakingPoolSnapshot, 0, len(ids))
+	idToSnapshot := make(map[int]models.ProposalStakingPoolSnapshot, len(snapshots))
+	for _, snapshot := range snapshots {
+		idToSnapshot[snapshot.ProposalID] = snapshot
+	}
+
+	for _, id := range ids {
+		snapshot, exists := idToSnapshot[id]
+		if exists {
+			result = append(result, &snapshot)
+		} else {
+			result = append(result, nil)
+		}
+	}
+
+	return result, nil
+}
+
 func (q *ProposalQuery) QueryProposalVotes(keys []models.ProposalVoteKey) ([]*models.ProposalVote, error) {
 	if len(keys) == 0 {
 		return []*models.ProposalVote{}, nil
@@ -375,6 +596,9 @@ func (q *ProposalQuery) QueryProposalVotes(keys []models.ProposalVoteKey) ([]*mo
 	err := q.session.NewSelect().
 		With("keys", q.session.NewValues(&keys).WithOrder()).
 		Model(&votes).
+		ModelTableExpr("(?) AS proposal_vote", newProposalVoteTableQuery(q.session)).
+		ColumnExpr("proposal_vote.*").
+		ColumnExpr("? AS voting_power", q.newVotingPowerExpr()).
 		Join("INNER JOIN keys ON (proposal_vote.proposal_id, proposal_vote.voter_address) = (keys.proposal_id, keys.address)").
 		Scan(q.ctx)
 	if err != nil {
@@ -400,6 +624,23 @@ func (q *ProposalQuery) QueryProposalVotes(keys []models.ProposalVoteKey) ([]*mo
 	return result, nil
 }
 
+// Query votes of a proposal cast or changed after the given height, in ascending order of height
+func (q *ProposalQuery) QueryProposalVotesAfterHeight(proposalID int, height int64) ([]models.ProposalVote, error) {
+	votes := make([]models.ProposalVote, 0)
+	err := q.session.NewSelect().
+		Model(&votes).
+		ModelTableExpr("(?) AS proposal_vote", newProposalVoteTableQuery(q.session)).
+		Where("proposal_vote.proposal_id = ?", proposalID).
+		Where("proposal_vote.height > ?", height).
+		Order("proposal_vote.height ASC").
+		Scan(q.ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return votes, nil
+}
+
 func (q *ProposalQuery) QueryProposalDeposits(keys []models.ProposalDepositKey) ([]*models.ProposalDeposit, error) {
 	if len(keys) == 0 {
 		return []*models.ProposalDeposit{}, nil
@@ -434,18 +675,20 @@ func (q *ProposalQuery) QueryProposalDeposits(keys []models.ProposalDepositKey)
 	return result, nil
 }
 
+// Count the proposals voted by the address for each option, a weighted vote is counted once for each of its options
 func (q *ProposalQuery) QueryProposalVoteCountByAddress(address string) (*models.ProposalTallyResult, error) {
 	res := make([]models.ProposalVoteOptionCount, 4)
 	err := q.session.NewSelect().
-		Model((*models.ProposalVote)(nil)).
+		TableExpr("proposal_vote").
 		Column("option").
-		ColumnExpr("count(*)").
+		ColumnExpr("count(DISTINCT proposal_id) AS count").
 		Where("voter_address = ?", address).
+		Where("weight::numeric > 0").
 		Group("option").
 		Scan(q.ctx, &res)
 
 	if err != nil {
-		return nil, nil
+		return nil, errors.WithStack(err)
 	}
 
 	distribution := models.ProposalTallyResult{}
@@ -465,3 +708,39 @@ func (q *ProposalQuery) QueryProposalVoteCountByAddress(address string) (*models
 	}
 	return &distribution, nil
 }
+
+// QueryProposalVotingPowerBreakdowns splits the voting power of votes on each proposal by whether the voter is a validator,
+// a weighted vote is split by the weights of its options
+func (q *ProposalQuery) QueryProposalVotingPowerBreakdowns(ids []int) ([]*models.ProposalVotingPowerBreakdown, error) {
+	if len(ids) == 0 {
+		return []*models.ProposalVotingPowerBreakdown{}, nil
+	}
+
+	var sums []models.ProposalVotingPowerSum
+	err := q.session.NewSelect().
+		TableExpr("proposal_vote").
+		Column("proposal_vote.proposal_id", "proposal_vote.option").
+		ColumnExpr("EXISTS (SELECT 1 FROM validator_info WHERE validator_info.self_delegate_address = proposal_vote.voter_address) AS is_validator").
+		ColumnExpr("TRUNC(SUM(? * proposal_vote.weight::numeric)) AS voting_power", q.newVotingPowerExpr()).
+		Where("proposal_vote.proposal_id IN (?)", bun.In(ids)).
+		GroupExpr("proposal_vote.proposal_id, proposal_vote.option, is_validator").
+		Scan(q.ctx, &sums)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	idToBreakdown := make(map[int]*models.ProposalVotingPowerBreakdown, len(ids))
+	for _, id := range ids {
+		idToBreakdown[id] = models.NewProposalVotingPowerBreakdown(id)
+	}
+	for _, sum := range sums {
+		idToBreakdown[sum.ProposalID].Add(sum)
+	}
+
+	result := make([]*models.ProposalVotingPowerBreakdown, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, idToBreakdown[id])
+	}
+
+	return result, nil
+}
@@ -2,7 +2,9 @@ package queries
 
 import (
 	"context"
+	"database/sql"
 	"strconv"
+	"time"
 
 	"github.com/oursky/likedao/pkg/models"
 	"github.com/pkg/errors"
@@ -16,10 +18,16 @@ type IUserReactionQuery interface {
 type ITargetReactionQuery interface {
 	ScopeUserAddress(address string) IUserReactionQuery
 	QueryTargetReactions() ([][]models.DBReactionCount, error)
+	QueryReactions() ([]models.Reaction, error)
+	QueryPaginatedReactions(reaction string, pagination Pagination) (*Paginated[models.Reaction], error)
 }
 
 type IReactionQuery interface {
 	ScopeProposals(proposalIDs []int) ITargetReactionQuery
+	ScopeTargets(targetType models.ReactionTargetType, targetIDs []string) ITargetReactionQuery
+	QueryReactionKinds(targetType *models.ReactionTargetType) ([]models.ReactionKind, error)
+	QueryReactionKind(targetType models.ReactionTargetType, reaction string) (*models.ReactionKind, error)
+	QueryReactionTimeline(targetType models.ReactionTargetType, targetID string, from time.Time, to time.Time, buckets int) ([]models.ReactionTimelineBucket, error)
 }
 
 type TargetReactionQuery struct {
@@ -57,6 +65,94 @@ func (q *ReactionQuery) ScopeProposals(proposalIds []int) ITargetReactionQuery {
 	}
 }
 
+func (q *ReactionQuery) ScopeTargets(targetType models.ReactionTargetType, targetIDs []string) ITargetReactionQuery {
+	return &TargetReactionQuery{
+		ReactionQuery:    *q,
+		scopedTargetType: string(targetType),
+		scopedTargetIds:  targetIDs,
+	}
+}
+
+// QueryReactionKinds returns the catalogue of the target type, or of all target types if targetType is nil
+func (q *ReactionQuery) QueryReactionKinds(targetType *models.ReactionTargetType) ([]models.ReactionKind, error) {
+	kinds := make([]models.ReactionKind, 0)
+	query := q.session.NewSelect().Model(&kinds)
+	if targetType != nil {
+		query = query.Where("target_type = ?", *targetType)
+	}
+
+	err := query.Order("target_type", "position", "reaction").Scan(q.ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return kinds, nil
+}
+
+// QueryReactionKind returns the reaction in the catalogue of the target type, nil if it is not in the catalogue
+func (q *ReactionQuery) QueryReactionKind(targetType models.ReactionTargetType, reaction string) (*models.ReactionKind, error) {
+	kind := new(models.ReactionKind)
+	err := q.session.NewSelect().
+		Model(kind).
+		Where("target_type = ? AND reaction = ?", targetType, reaction).
+		Scan(q.ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return kind, nil
+}
+
+// QueryReactionTimeline splits [from, to) into buckets of equal length, and counts the reactions to the target
+// at the end of each bucket by replaying reaction events
+func (q *ReactionQuery) QueryReactionTimeline(
+	targetType models.ReactionTargetType,
+	targetID string,
+	from time.Time,
+	to time.Time,
+	buckets int,
+) ([]models.ReactionTimelineBucket, error) {
+	bucketSeconds := to.Sub(from).Seconds() / float64(buckets)
+
+	// The latest event of each address before the end of the bucket is its reaction at that time
+	reactionsQuery := q.session.NewSelect().
+		Model((*models.ReactionEvent)(nil)).
+		DistinctOn("reaction_event.address").
+		ColumnExpr("reaction_event.reaction").
+		Where("reaction_event.target_type = ?", targetType).
+		Where("reaction_event.target_id = ?", targetID).
+		Where("reaction_event.created_at < bucket.end_time").
+		OrderExpr("reaction_event.address, reaction_event.created_at DESC, reaction_event.id DESC")
+
+	reactionCountQuery := q.session.NewSelect().
+		TableExpr("(?) AS state", reactionsQuery).
+		ColumnExpr("state.reaction, count(*) AS count").
+		Where("state.reaction IS NOT NULL").
+		Group("state.reaction")
+
+	result := make([]models.ReactionTimelineBucket, 0, buckets)
+	err := q.session.NewSelect().
+		TableExpr("generate_series(0, ?) AS i", buckets-1).
+		Join(
+			"CROSS JOIN LATERAL (SELECT ?::timestamp + make_interval(secs => i * ?) AS time, ?::timestamp + make_interval(secs => (i + 1) * ?) AS end_time) AS bucket",
+			from.UTC(), bucketSeconds, from.UTC(), bucketSeconds,
+		).
+		Join("LEFT JOIN LATERAL (?) AS c ON TRUE", reactionCountQuery).
+		ColumnExpr("bucket.time").
+		ColumnExpr("COALESCE(json_agg(json_build_object('reaction', c.reaction, 'count', c.count) ORDER BY c.count DESC, c.reaction) FILTER (WHERE c.reaction IS NOT NULL), '[]')::jsonb AS reactions").
+		Group("bucket.time").
+		Order("bucket.time").
+		Scan(q.ctx, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
 func (q *TargetReactionQuery) ScopeUserAddress(address string) IUserReactionQuery {
 	return &UserReactionQuery{TargetReactionQuery: *q, scopedUserAddress: address}
 }
@@ -119,9 +215,41 @@ func (q *TargetReactionQuery) QueryTargetReactions() ([][]models.DBReactionCount
 	return result, nil
 }
 
+func (q *TargetReactionQuery) QueryReactions() ([]models.Reaction, error) {
+	reactions := make([]models.Reaction, 0)
+	if err := q.NewQuery().Model(&reactions).Scan(q.ctx); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return reactions, nil
+}
+
+// QueryPaginatedReactions returns reactions of the reaction to the scoped targets, most recently set first
+func (q *TargetReactionQuery) QueryPaginatedReactions(reaction string, pagination Pagination) (*Paginated[models.Reaction], error) {
+	totalCount, err := q.NewQuery().Where("reaction.reaction = ?", reaction).Count(q.ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var reactions []models.Reaction
+	keys := []SortKey{
+		NewSortKey(models.SortDesc, "reaction.updated_at"),
+		NewSortKey(models.SortDesc, "reaction.id"),
+	}
+	query := q.NewQuery().Model(&reactions).Where("reaction.reaction = ?", reaction)
+	res, err := Paginate(q.ctx, query, &reactions, pagination, keys, func(reaction models.Reaction) []string {
+		return []string{reaction.UpdatedAt.UTC().Format(time.RFC3339Nano), reaction.ID}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res.PaginationInfo.TotalCount = totalCount
+	return res, nil
+}
+
 func (q *UserReactionQuery) NewQuery() *bun.SelectQuery {
-	return q.session.NewSelect().
-		Model((*models.Reaction)(nil)).
+	return q.TargetReactionQuery.NewQuery().
 		Where("address = (?)", q.scopedUserAddress)
 }
 
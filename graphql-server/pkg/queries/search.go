@@ -0,0 +1,10 @@
+package queries
+
+import "strings"
+
+var likePatternEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escape LIKE / ILIKE wildcards so that user input is matched literally
+func escapeLikePattern(pattern string) string {
+	return likePatternEscaper.Replace(pattern)
+}
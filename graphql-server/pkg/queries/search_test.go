@@ -0,0 +1,169 @@
+package queries
+
+import (
+	"testing"
+
+	"github.com/oursky/likedao/pkg/models"
+)
+
+func Test_escapeLikePattern(t *testing.T) {
+	testCases := []struct {
+		name     string
+		pattern  string
+		expected string
+	}{
+		{"No wildcards", "proposal", "proposal"},
+		{"Percent", "100%", `100\%`},
+		{"Underscore", "snake_case", `snake\_case`},
+		{"Backslash", `a\b`, `a\\b`},
+		{"Escaped wildcard", `\%`, `\\\%`},
+		{"Empty", "", ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if escaped := escapeLikePattern(testCase.pattern); escaped != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, escaped)
+			}
+		})
+	}
+}
+
+func Test_searchProposalID(t *testing.T) {
+	testCases := []struct {
+		name         string
+		searchTerm   string
+		proposalID   int
+		isProposalID bool
+	}{
+		{"Number", "12", 12, true},
+		{"Number with hash", "#12", 12, true},
+		{"Number with spaces", "  #12 ", 12, true},
+		{"Zero", "0", 0, true},
+		{"Negative", "-1", 0, false},
+		{"Hash only", "#", 0, false},
+		{"Double hash", "##12", 0, false},
+		{"Text", "community pool", 0, false},
+		{"Number with text", "12 votes", 0, false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			query := (&ProposalQuery{}).ScopeProposalSearchTerm(testCase.searchTerm).(*ProposalQuery)
+			proposalID, isProposalID := query.searchProposalID()
+			if isProposalID != testCase.isProposalID {
+				t.Fatalf("expected is proposal ID %t, got %t", testCase.isProposalID, isProposalID)
+			}
+			if isProposalID && proposalID != testCase.proposalID {
+				t.Errorf("expected proposal ID %d, got %d", testCase.proposalID, proposalID)
+			}
+		})
+	}
+}
+
+func Test_ProposalSortKeys(t *testing.T) {
+	asc := models.SortAsc
+
+	testCases := []struct {
+		name       string
+		searchTerm string
+		order      *models.ProposalSort
+		exprs      []string
+	}{
+		{
+			name:  "Without search",
+			exprs: []string{"proposal.submit_time", "proposal.id"},
+		},
+		{
+			name:       "Search ranks by relevance first",
+			searchTerm: "upgrade",
+			exprs:      []string{proposalSearchRank, "(proposal.title ILIKE ?)", "proposal.submit_time", "proposal.id"},
+		},
+		{
+			name:       "Search by proposal ID ranks the proposal first",
+			searchTerm: "#3",
+			exprs:      []string{"(proposal.id = ?)", proposalSearchRank, "(proposal.title ILIKE ?)", "proposal.submit_time", "proposal.id"},
+		},
+		{
+			name:       "Order takes precedence over relevance",
+			searchTerm: "upgrade",
+			order:      &models.ProposalSort{SubmitTime: &asc},
+			exprs:      []string{"proposal.submit_time", proposalSearchRank, "(proposal.title ILIKE ?)", "proposal.id"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			query := (&ProposalQuery{}).
+				ScopeProposalSearchTerm(testCase.searchTerm).
+				ScopeProposalOrder(testCase.order).(*ProposalQuery)
+			keys, keyValues := query.sortKeys()
+
+			if len(keys) != len(testCase.exprs) {
+				t.Fatalf("expected %d keys, got %d", len(testCase.exprs), len(keys))
+			}
+			for i, key := range keys {
+				if key.Expr != testCase.exprs[i] {
+					t.Errorf("expected key %d to be %s, got %s", i, testCase.exprs[i], key.Expr)
+				}
+			}
+			if values := keyValues(models.Proposal{ID: 3}); len(values) != len(keys) {
+				t.Errorf("expected %d key values, got %d", len(keys), len(values))
+			}
+			if testCase.order != nil && (keys[0].Desc || keys[len(keys)-1].Desc) {
+				t.Errorf("expected ordered keys to be ascending")
+			}
+		})
+	}
+}
+
+func Test_ValidatorSearchTerm(t *testing.T) {
+	validator := models.Validator{
+		ConsensusAddress: "likevalcons1abc",
+		Info:             &models.ValidatorInfo{OperatorAddress: "likevaloper1abc", SelfDelegateAddress: "like1abc"},
+	}
+
+	testCases := []struct {
+		name         string
+		searchTerm   string
+		scoped       string
+		addressMatch string
+	}{
+		{"Moniker", "oursky", "oursky", "false"},
+		{"Trimmed moniker", "  oursky\t", "oursky", "false"},
+		{"Whitespace only", "   ", "", ""},
+		{"Consensus address", "likevalcons1abc", "likevalcons1abc", "true"},
+		{"Trimmed operator address", " likevaloper1abc ", "likevaloper1abc", "true"},
+		{"Self delegate address", "like1abc", "like1abc", "true"},
+		{"Address prefix", "like1", "like1", "false"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			query := (&ValidatorQuery{}).ScopeValidatorSearchTerm(testCase.searchTerm).(*ValidatorQuery)
+			if query.scopeValidatorSearchTerm != testCase.scoped {
+				t.Fatalf("expected search term %q, got %q", testCase.scoped, query.scopeValidatorSearchTerm)
+			}
+
+			keys, keyValues := query.sortKeys()
+			values := keyValues(validator)
+			if testCase.addressMatch == "" {
+				if keys[0].Expr == validatorAddressMatchExpr {
+					t.Errorf("expected no address match key without search term")
+				}
+				return
+			}
+			if keys[0].Expr != validatorAddressMatchExpr {
+				t.Fatalf("expected address match key first, got %s", keys[0].Expr)
+			}
+			for _, arg := range keys[0].Args {
+				if arg != testCase.scoped {
+					t.Errorf("expected address match argument %q, got %q", testCase.scoped, arg)
+				}
+			}
+			if values[0] != testCase.addressMatch {
+				t.Errorf("expected address match %s, got %s", testCase.addressMatch, values[0])
+			}
+		})
+	}
+}
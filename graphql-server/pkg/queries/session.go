@@ -0,0 +1,64 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/oursky/likedao/pkg/models"
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+type ISessionQuery interface {
+	QuerySessionByID(id string) (*models.Session, error)
+	QuerySessionsByIDs(ids []string) ([]models.Session, error)
+	QueryActiveSessionsByAddress(address string) ([]models.Session, error)
+}
+
+type SessionQuery struct {
+	ctx     context.Context
+	session *bun.DB
+}
+
+func NewSessionQuery(ctx context.Context, session *bun.DB) ISessionQuery {
+	return &SessionQuery{ctx: ctx, session: session}
+}
+
+func (q *SessionQuery) QuerySessionByID(id string) (*models.Session, error) {
+	session := new(models.Session)
+	err := q.session.NewSelect().Model(session).Where("id = ?", id).Scan(q.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (q *SessionQuery) QuerySessionsByIDs(ids []string) ([]models.Session, error) {
+	sessions := make([]models.Session, 0, len(ids))
+	err := q.session.NewSelect().
+		Model(&sessions).
+		Where("id IN (?)", bun.In(ids)).
+		Scan(q.ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return sessions, nil
+}
+
+func (q *SessionQuery) QueryActiveSessionsByAddress(address string) ([]models.Session, error) {
+	sessions := make([]models.Session, 0)
+	err := q.session.NewSelect().
+		Model(&sessions).
+		Where("address = ?", address).
+		Where("revoked = ?", false).
+		Where("expires_at > ?", time.Now().UTC()).
+		Order("last_seen_at DESC").
+		Scan(q.ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return sessions, nil
+}
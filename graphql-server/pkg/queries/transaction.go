@@ -0,0 +1,183 @@
+package queries
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/oursky/likedao/pkg/models"
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+type ITransactionQuery interface {
+	ScopeTransactionAddress(address string) ITransactionQuery
+	QueryPaginatedTransactions(pagination Pagination) (*Paginated[models.Transaction], error)
+	QueryTransactionCount() (int, error)
+	QueryTransactionsByHashes(hashes []string) ([]*models.Transaction, error)
+	QueryTransactionsByHeights(heights []int64) ([][]models.Transaction, error)
+	QueryMessagesByTransactionHashes(hashes []string) ([][]models.TransactionMessage, error)
+}
+
+type TransactionQuery struct {
+	ctx     context.Context
+	session *bun.DB
+
+	scopedAddress string
+}
+
+func NewTransactionQuery(ctx context.Context, session *bun.DB) ITransactionQuery {
+	return &TransactionQuery{ctx: ctx, session: session}
+}
+
+// ScopeTransactionAddress filters transactions with a message involving the address
+func (q *TransactionQuery) ScopeTransactionAddress(address string) ITransactionQuery {
+	var newQuery = *q
+	newQuery.scopedAddress = address
+	return &newQuery
+}
+
+// Messages involving the scoped address. Containment instead of ANY is used for the condition
+// so that the GIN index on involved_accounts_addresses can be used
+func (q *TransactionQuery) newInvolvedMessageQuery() *bun.SelectQuery {
+	return q.session.NewSelect().
+		Model((*models.TransactionMessage)(nil)).
+		Where("message.involved_accounts_addresses @> ARRAY[?]::TEXT[]", q.scopedAddress)
+}
+
+func (q *TransactionQuery) NewQuery(model interface{}) *bun.SelectQuery {
+	query := q.session.NewSelect().Model(model)
+
+	if q.scopedAddress != "" {
+		query = query.Where("transaction.hash IN (?)", q.newInvolvedMessageQuery().Column("message.transaction_hash"))
+	}
+
+	return query
+}
+
+// QueryTransactionCount counts transactions involving the scoped address from the indexed messages alone
+func (q *TransactionQuery) QueryTransactionCount() (int, error) {
+	if q.scopedAddress == "" {
+		return 0, errors.New("transaction count must be scoped by address")
+	}
+
+	var count int
+	err := q.newInvolvedMessageQuery().
+		ColumnExpr("COUNT(DISTINCT message.transaction_hash)").
+		Scan(q.ctx, &count)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	return count, nil
+}
+
+func (q *TransactionQuery) QueryPaginatedTransactions(pagination Pagination) (*Paginated[models.Transaction], error) {
+	var transactions []models.Transaction
+	keys := []SortKey{
+		NewSortKey(models.SortDesc, "transaction.height"),
+		NewSortKey(models.SortDesc, "transaction.hash"),
+	}
+	res, err := Paginate(q.ctx, q.NewQuery(&transactions), &transactions, pagination, keys, func(transaction models.Transaction) []string {
+		return []string{strconv.FormatInt(transaction.Height, 10), transaction.Hash}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (q *TransactionQuery) QueryTransactionsByHashes(hashes []string) ([]*models.Transaction, error) {
+	if len(hashes) == 0 {
+		return []*models.Transaction{}, nil
+	}
+
+	transactions := make([]models.Transaction, 0)
+	uppercasedHashes := make([]string, 0, len(hashes))
+	for _, hash := range hashes {
+		uppercasedHashes = append(uppercasedHashes, strings.ToUpper(hash))
+	}
+	if err := q.session.NewSelect().Model(&transactions).Where("hash IN (?)", bun.In(uppercasedHashes)).Scan(q.ctx); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result := make([]*models.Transaction, 0, len(hashes))
+	hashToTransaction := make(map[string]models.Transaction, len(transactions))
+	for _, transaction := range transactions {
+		hashToTransaction[transaction.Hash] = transaction
+	}
+
+	for _, hash := range uppercasedHashes {
+		transaction, exists := hashToTransaction[hash]
+		if exists {
+			result = append(result, &transaction)
+		} else {
+			result = append(result, nil)
+		}
+	}
+
+	return result, nil
+}
+
+func (q *TransactionQuery) QueryTransactionsByHeights(heights []int64) ([][]models.Transaction, error) {
+	if len(heights) == 0 {
+		return [][]models.Transaction{}, nil
+	}
+
+	transactions := make([]models.Transaction, 0)
+	if err := q.session.NewSelect().
+		Model(&transactions).
+		Where("height IN (?)", bun.In(heights)).
+		Order("hash ASC").
+		Scan(q.ctx); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	heightToTransactions := make(map[int64][]models.Transaction, len(heights))
+	for _, transaction := range transactions {
+		heightToTransactions[transaction.Height] = append(heightToTransactions[transaction.Height], transaction)
+	}
+
+	result := make([][]models.Transaction, 0, len(heights))
+	for _, height := range heights {
+		blockTransactions, exists := heightToTransactions[height]
+		if !exists {
+			blockTransactions = []models.Transaction{}
+		}
+		result = append(result, blockTransactions)
+	}
+
+	return result, nil
+}
+
+func (q *TransactionQuery) QueryMessagesByTransactionHashes(hashes []string) ([][]models.TransactionMessage, error) {
+	if len(hashes) == 0 {
+		return [][]models.TransactionMessage{}, nil
+	}
+
+	messages := make([]models.TransactionMessage, 0)
+	if err := q.session.NewSelect().
+		Model(&messages).
+		Where("transaction_hash IN (?)", bun.In(hashes)).
+		Order("index ASC").
+		Scan(q.ctx); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	hashToMessages := make(map[string][]models.TransactionMessage, len(hashes))
+	for _, message := range messages {
+		hashToMessages[message.TransactionHash] = append(hashToMessages[message.TransactionHash], message)
+	}
+
+	result := make([][]models.TransactionMessage, 0, len(hashes))
+	for _, hash := range hashes {
+		transactionMessages, exists := hashToMessages[hash]
+		if !exists {
+			transactionMessages = []models.TransactionMessage{}
+		}
+		result = append(result, transactionMessages)
+	}
+
+	return result, nil
+}
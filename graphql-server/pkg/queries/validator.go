@@ -3,6 +3,9 @@ package queries
 import (
 	"context"
 	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
 
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/oursky/likedao/pkg/config"
@@ -13,13 +16,17 @@ import (
 
 type IValidatorQuery interface {
 	ScopeValidatorStatus(filter models.ValidatorStatusFilter) IValidatorQuery
+	ScopeValidatorSearchTerm(searchTerm string) IValidatorQuery
 	WithProposalDeposits() IValidatorQuery
 	WithProposalVotes() IValidatorQuery
 	ValidatorOrderBy(order models.ValidatorSort) IValidatorQuery
-	QueryPaginatedValidators(first int, after int, includeAddresses []string) (*Paginated[models.Validator], error)
+	QueryPaginatedValidators(pagination Pagination, includeAddresses []string) (*Paginated[models.Validator], error)
+	QueryValidators() ([]models.Validator, error)
 	QueryValidatorsByConsensusAddresses(addresses []string) ([]*models.Validator, error)
 	QueryValidatorsBySelfDelegationAddresses(addresses []string) ([]*models.Validator, error)
+	QueryValidatorsByOperatorAddresses(addresses []string) ([]*models.Validator, error)
 	QueryRelativeTotalProposalCounts(addresses []string) ([]*int, error)
+	QueryGovernanceRecords(addresses []string) ([]*models.ValidatorGovernanceRecord, error)
 }
 
 type ValidatorQuery struct {
@@ -30,7 +37,8 @@ type ValidatorQuery struct {
 	withProposalVotes    bool
 	withProposalDeposits bool
 
-	scopeValidatorStatus *models.ValidatorStatusFilter
+	scopeValidatorStatus     *models.ValidatorStatusFilter
+	scopeValidatorSearchTerm string
 
 	validatorOrderBy *models.ValidatorSort
 }
@@ -57,6 +65,12 @@ func (q *ValidatorQuery) ScopeValidatorStatus(status models.ValidatorStatusFilte
 	return &newQuery
 }
 
+func (q *ValidatorQuery) ScopeValidatorSearchTerm(searchTerm string) IValidatorQuery {
+	var newQuery = *q
+	newQuery.scopeValidatorSearchTerm = strings.TrimSpace(searchTerm)
+	return &newQuery
+}
+
 func (q *ValidatorQuery) ValidatorOrderBy(order models.ValidatorSort) IValidatorQuery {
 	var newQuery = *q
 	newQuery.validatorOrderBy = &order
@@ -92,11 +106,9 @@ func (q *ValidatorQuery) NewQuery(model interface{}, includeAddresses []string)
 				ColumnExpr("COALESCE((((1 - commission::decimal) * ((?) * (?))) / (?)::numeric), 0) as commission__expected_returns", inflationQuery, nativeSupplyQuery, bondedPoolQuery)
 		}).
 		Relation("SigningInfo", func(signingInfoQuery *bun.SelectQuery) *bun.SelectQuery {
-			// Calculate uptime = (1 - signing_info.missed_blocks / (latest_block.height - signing_info.start_height))
-			latestBlockQuery := q.session.NewSelect().Model((*models.Block)(nil)).Order("timestamp DESC").Column("height").Limit(1)
 			return signingInfoQuery.
 				Column("validator_address", "start_height", "index_offset", "jailed_until", "tombstoned", "missed_blocks_counter", "height").
-				ColumnExpr("COALESCE((1 - (missed_blocks_counter::decimal / ((?) - start_height::decimal))), 0) as signing_info__uptime", latestBlockQuery)
+				ColumnExpr("? as signing_info__uptime", q.newUptimeExpr())
 		}).
 		Relation("Status").
 		// To handle gql resolving when info is provided but validator isn't
@@ -107,7 +119,9 @@ func (q *ValidatorQuery) NewQuery(model interface{}, includeAddresses []string)
 	}
 
 	if q.withProposalVotes {
-		query = query.Relation("Info.ProposalVotes")
+		query = query.Relation("Info.ProposalVotes", func(sq *bun.SelectQuery) *bun.SelectQuery {
+			return sq.ModelTableExpr("(?) AS proposal_vote", newProposalVoteTableQuery(q.session))
+		})
 	}
 
 	if q.withProposalDeposits {
@@ -133,56 +147,149 @@ func (q *ValidatorQuery) NewQuery(model interface{}, includeAddresses []string)
 		})
 	}
 
+	if q.scopeValidatorSearchTerm != "" {
+		likePattern := fmt.Sprintf("%%%s%%", escapeLikePattern(q.scopeValidatorSearchTerm))
+		query = query.WhereGroup(" AND ", func(group *bun.SelectQuery) *bun.SelectQuery {
+			return group.
+				WhereOr("description.moniker ILIKE ?", likePattern).
+				WhereOr("description.identity ILIKE ?", likePattern).
+				WhereOr("description.website ILIKE ?", likePattern).
+				WhereOr("description.details ILIKE ?", likePattern).
+				WhereOr(validatorAddressMatchExpr, q.scopeValidatorSearchTerm, q.scopeValidatorSearchTerm, q.scopeValidatorSearchTerm)
+		})
+	}
+
+	return query
+}
+
+// Info of a validator may be missing, so the match is coalesced for sorting
+const validatorAddressMatchExpr = "COALESCE(info.operator_address = ? OR validator.consensus_address = ? OR info.self_delegate_address = ?, false)"
+
+// Calculate uptime = (1 - signing_info.missed_blocks / (latest_block.height - signing_info.start_height)),
+// rounded so that it can be compared exactly in cursors
+func (q *ValidatorQuery) newUptimeExpr() bun.Safe {
+	latestBlockQuery := q.session.NewSelect().Model((*models.Block)(nil)).Order("timestamp DESC").Column("height").Limit(1)
+	return bun.Safe(q.session.Formatter().FormatQuery(
+		`ROUND(COALESCE((1 - ("signing_info"."missed_blocks_counter"::decimal / ((?) - "signing_info"."start_height"::decimal))), 0), 10)`,
+		latestBlockQuery,
+	))
+}
+
+// sortKeys returns the keys validators are sorted by and a function returning the values of keys of a validator
+func (q *ValidatorQuery) sortKeys() ([]SortKey, func(validator models.Validator) []string) {
+	keys, keyValues := q.orderSortKeys()
+	if q.scopeValidatorSearchTerm == "" {
+		return keys, keyValues
+	}
+
+	// Validators with an address exactly matching the search term come first
+	searchTerm := q.scopeValidatorSearchTerm
+	addressMatchKey := NewSortKey(models.SortDesc, validatorAddressMatchExpr, searchTerm, searchTerm, searchTerm)
+	return append([]SortKey{addressMatchKey}, keys...), func(validator models.Validator) []string {
+		addressMatch := validator.ConsensusAddress == searchTerm
+		if validator.Info != nil {
+			addressMatch = addressMatch ||
+				validator.Info.OperatorAddress == searchTerm ||
+				validator.Info.SelfDelegateAddress == searchTerm
+		}
+		return append([]string{strconv.FormatBool(addressMatch)}, keyValues(validator)...)
+	}
+}
+
+func (q *ValidatorQuery) orderSortKeys() ([]SortKey, func(validator models.Validator) []string) {
+	consensusAddressKey := NewSortKey(models.SortAsc, "validator.consensus_address")
+
+	order := models.ValidatorSort{}
 	if q.validatorOrderBy != nil {
-		if q.validatorOrderBy.Name != nil {
-			query = query.Order(fmt.Sprintf("moniker %s", q.validatorOrderBy.Name)).Order(fmt.Sprintf("operator_address %s", q.validatorOrderBy.Name))
+		order = *q.validatorOrderBy
+	}
+
+	switch {
+	case order.VotingPower != nil:
+		consensusAddressKey.Desc = *order.VotingPower == models.SortDesc
+		return []SortKey{
+			NewSortKey(*order.VotingPower, "COALESCE(voting_power.voting_power, 0)"),
+			consensusAddressKey,
+		}, func(validator models.Validator) []string {
+			votingPower := "0"
+			if validator.VotingPower != nil {
+				votingPower = validator.VotingPower.VotingPower.String()
+			}
+			return []string{votingPower, validator.ConsensusAddress}
 		}
-		if q.validatorOrderBy.VotingPower != nil {
-			query = query.Order(fmt.Sprintf("voting_power__relative_voting_power %s", q.validatorOrderBy.VotingPower))
+	case order.ExpectedReturns != nil:
+		// Expected returns decrease with commission rate, validators without commission have no returns
+		commissionSort := models.SortAsc
+		if *order.ExpectedReturns == models.SortAsc {
+			commissionSort = models.SortDesc
 		}
-		if q.validatorOrderBy.ExpectedReturns != nil {
-			query = query.Order(fmt.Sprintf("commission__expected_returns %s", q.validatorOrderBy.ExpectedReturns))
+		consensusAddressKey.Desc = *order.ExpectedReturns == models.SortDesc
+		return []SortKey{
+			NewSortKey(commissionSort, "COALESCE(commission.commission, 1)"),
+			consensusAddressKey,
+		}, func(validator models.Validator) []string {
+			commission := "1"
+			if validator.Commission != nil {
+				commission = (*big.Float)(&validator.Commission.Commission).Text('f', -1)
+			}
+			return []string{commission, validator.ConsensusAddress}
+		}
+	case order.Uptime != nil:
+		consensusAddressKey.Desc = *order.Uptime == models.SortDesc
+		return []SortKey{
+			NewSortKey(*order.Uptime, "?", q.newUptimeExpr()),
+			consensusAddressKey,
+		}, func(validator models.Validator) []string {
+			uptime := "0"
+			if validator.SigningInfo != nil {
+				uptime = strconv.FormatFloat(validator.SigningInfo.Uptime, 'f', -1, 64)
+			}
+			return []string{uptime, validator.ConsensusAddress}
 		}
-		if q.validatorOrderBy.Uptime != nil {
-			query = query.Order(fmt.Sprintf("signing_info__uptime %s", q.validatorOrderBy.Uptime))
+	default:
+		sort := models.SortAsc
+		if order.Name != nil {
+			sort = *order.Name
 		}
-	} else {
-		query = query.Order("moniker ASC").Order("operator_address ASC")
+		return monikerSortKeys(sort, `"description"."moniker"`, "validator.consensus_address"),
+			func(validator models.Validator) []string {
+				return monikerSortKeyValues(validator.Description, validator.ConsensusAddress)
+			}
 	}
+}
 
-	return query
+// monikerSortKeys sorts by validator moniker with the given address as tiebreaker, accounts without moniker come last
+func monikerSortKeys(sort models.Sort, monikerExpr string, addressExpr string) []SortKey {
+	return []SortKey{
+		NewSortKey(models.SortAsc, fmt.Sprintf("(COALESCE(%s, '') = '')", monikerExpr)),
+		NewSortKey(sort, fmt.Sprintf("COALESCE(%s, '')", monikerExpr)),
+		NewSortKey(sort, addressExpr),
+	}
 }
 
-func (q *ValidatorQuery) QueryPaginatedValidators(first int, after int, includeAddresses []string) (*Paginated[models.Validator], error) {
-	var validators []models.Validator
-	query := q.NewQuery(&validators, includeAddresses)
+func monikerSortKeyValues(description *models.ValidatorDescription, address string) []string {
+	moniker := ""
+	if description != nil {
+		moniker = description.Moniker
+	}
+	return []string{strconv.FormatBool(moniker == ""), moniker, address}
+}
 
+func (q *ValidatorQuery) QueryPaginatedValidators(pagination Pagination, includeAddresses []string) (*Paginated[models.Validator], error) {
 	totalCount, err := q.NewQuery((*models.Validator)(nil), includeAddresses).Count(q.ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	query.Limit(first + 1).Offset(after)
-
-	if err := query.Scan(q.ctx); err != nil {
-		return nil, errors.WithStack(err)
-	}
-
-	hasNextPage := len(validators) > first
-	hasPreviousPage := after > 0
-
-	if len(validators) > first {
-		validators = validators[:first]
+	var validators []models.Validator
+	keys, keyValues := q.sortKeys()
+	res, err := Paginate(q.ctx, q.NewQuery(&validators, includeAddresses), &validators, pagination, keys, keyValues)
+	if err != nil {
+		return nil, err
 	}
 
-	return &Paginated[models.Validator]{
-		Items: validators,
-		PaginationInfo: PaginationInfo{
-			HasNext:     hasNextPage,
-			HasPrevious: hasPreviousPage,
-			TotalCount:  totalCount,
-		},
-	}, nil
+	res.PaginationInfo.TotalCount = totalCount
+	return res, nil
 }
 
 func (q *ValidatorQuery) QueryValidatorsByConsensusAddresses(addresses []string) ([]*models.Validator, error) {
@@ -215,6 +322,16 @@ func (q *ValidatorQuery) QueryValidatorsByConsensusAddresses(addresses []string)
 
 }
 
+func (q *ValidatorQuery) QueryValidators() ([]models.Validator, error) {
+	validators := make([]models.Validator, 0)
+	err := q.NewQuery(&validators, []string{}).Scan(q.ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return validators, nil
+}
+
 func (q *ValidatorQuery) QueryValidatorsBySelfDelegationAddresses(addresses []string) ([]*models.Validator, error) {
 	if len(addresses) == 0 {
 		return []*models.Validator{}, nil
@@ -245,6 +362,40 @@ func (q *ValidatorQuery) QueryValidatorsBySelfDelegationAddresses(addresses []st
 
 }
 
+func (q *ValidatorQuery) QueryValidatorsByOperatorAddresses(addresses []string) ([]*models.Validator, error) {
+	if len(addresses) == 0 {
+		return []*models.Validator{}, nil
+	}
+
+	var validators []models.Validator
+	err := q.NewQuery(&validators, addresses).Scan(q.ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result := make([]*models.Validator, 0, len(validators))
+	addressToValidator := make(map[string]models.Validator, len(validators))
+	for _, validator := range validators {
+		addressToValidator[validator.Info.OperatorAddress] = validator
+	}
+
+	for _, address := range addresses {
+		validator, exists := addressToValidator[address]
+		if exists {
+			result = append(result, &validator)
+		} else {
+			result = append(result, nil)
+		}
+	}
+
+	return result, nil
+}
+
+// Joins the block a validator started at as start_block. The start block is not indexed if the validator started before
+// the indexer or at a skipped height, the first indexed block after it is used instead so that such validators are not
+// treated as having no proposals since their start
+const validatorStartBlockJoin = "LEFT JOIN LATERAL (SELECT block.timestamp FROM block WHERE block.height >= signing_info.start_height ORDER BY block.height ASC LIMIT 1) AS start_block ON TRUE"
+
 func (q *ValidatorQuery) QueryRelativeTotalProposalCounts(addresses []string) ([]*int, error) {
 	if len(addresses) == 0 {
 		return []*int{}, nil
@@ -260,9 +411,9 @@ func (q *ValidatorQuery) QueryRelativeTotalProposalCounts(addresses []string) ([
 			NewSelect().
 			Model((*models.Proposal)(nil)).
 			ColumnExpr("COUNT(id)").
-			Where("submit_time >= block.timestamp").
+			Where("submit_time >= start_block.timestamp").
 			WhereOr("signing_info.start_height = 0")).
-		Join("LEFT JOIN block ON signing_info.start_height = block.height").
+		Join(validatorStartBlockJoin).
 		Where("validator.consensus_address IN (?)", bun.In(addresses)).
 		Scan(q.ctx, &counts)
 
@@ -287,3 +438,76 @@ func (q *ValidatorQuery) QueryRelativeTotalProposalCounts(addresses []string) ([
 
 	return result, nil
 }
+
+// QueryGovernanceRecords returns a record for each validator, validators not found have empty records.
+// Proposals are counted since the start of a validator in the same way as QueryRelativeTotalProposalCounts
+func (q *ValidatorQuery) QueryGovernanceRecords(addresses []string) ([]*models.ValidatorGovernanceRecord, error) {
+	if len(addresses) == 0 {
+		return []*models.ValidatorGovernanceRecord{}, nil
+	}
+
+	proposalsQuery := q.session.NewSelect().
+		Model((*models.Validator)(nil)).
+		ColumnExpr("validator.consensus_address").
+		ColumnExpr("COALESCE(info.self_delegate_address, '') AS voter_address").
+		ColumnExpr("proposal.id AS proposal_id").
+		ColumnExpr("proposal.status AS proposal_status").
+		Join("JOIN validator_signing_info AS signing_info ON signing_info.validator_address = validator.consensus_address").
+		Join(validatorStartBlockJoin).
+		Join("JOIN proposal ON proposal.submit_time >= start_block.timestamp OR signing_info.start_height = 0").
+		Join("LEFT JOIN validator_info AS info ON info.consensus_address = validator.consensus_address").
+		Where("validator.consensus_address IN (?)", bun.In(addresses)).
+		// Proposals which have not entered voting period cannot be voted on
+		Where("proposal.status IN (?)", bun.In([]models.ProposalStatus{
+			models.ProposalStatusVotingPeriod,
+			models.ProposalStatusPassed,
+			models.ProposalStatusRejected,
+			models.ProposalStatusFailed,
+		}))
+
+	// Votes are filtered to the proposals of the validators before ranking, the filter keeps whole partitions so
+	// the counts are not affected. Voters at the same height are not earlier than each other
+	votesQuery := q.session.NewSelect().
+		TableExpr("(?) AS proposal_vote", newProposalVoteTableQuery(q.session)).
+		Column("proposal_id", "voter_address", "option").
+		ColumnExpr("COUNT(*) OVER (PARTITION BY proposal_id) AS voter_count").
+		ColumnExpr("RANK() OVER (PARTITION BY proposal_id ORDER BY height ASC) - 1 AS earlier_voter_count").
+		Where("proposal_vote.proposal_id IN (SELECT validator_proposal.proposal_id FROM validator_proposal)")
+
+	var entries []models.ValidatorGovernanceRecordEntry
+	err := q.session.NewSelect().
+		With("validator_proposal", proposalsQuery).
+		With("vote", votesQuery).
+		TableExpr("validator_proposal").
+		ColumnExpr("validator_proposal.consensus_address").
+		ColumnExpr("validator_proposal.voter_address").
+		ColumnExpr("validator_proposal.proposal_id").
+		ColumnExpr("validator_proposal.proposal_status").
+		ColumnExpr("vote.option AS vote_option").
+		ColumnExpr("COALESCE(vote.voter_count, 0) AS voter_count").
+		ColumnExpr("COALESCE(vote.earlier_voter_count, 0) AS earlier_voter_count").
+		Join("LEFT JOIN vote ON vote.proposal_id = validator_proposal.proposal_id AND vote.voter_address = validator_proposal.voter_address").
+		Order("validator_proposal.proposal_id DESC").
+		Scan(q.ctx, &entries)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	addressToRecord := make(map[string]*models.ValidatorGovernanceRecord, len(addresses))
+	for _, address := range addresses {
+		addressToRecord[address] = &models.ValidatorGovernanceRecord{
+			Entries: []models.ValidatorGovernanceRecordEntry{},
+		}
+	}
+	for _, entry := range entries {
+		record := addressToRecord[entry.ConsensusAddress]
+		record.Entries = append(record.Entries, entry)
+	}
+
+	result := make([]*models.ValidatorGovernanceRecord, 0, len(addresses))
+	for _, address := range addresses {
+		result = append(result, addressToRecord[address])
+	}
+
+	return result, nil
+}
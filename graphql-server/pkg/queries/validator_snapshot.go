@@ -0,0 +1,46 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/oursky/likedao/pkg/models"
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+type IValidatorSnapshotQuery interface {
+	QueryValidatorHistory(consensusAddress string, from time.Time, to time.Time, interval models.ValidatorHistoryInterval) ([]models.ValidatorSnapshot, error)
+}
+
+type ValidatorSnapshotQuery struct {
+	ctx     context.Context
+	session *bun.DB
+}
+
+func NewValidatorSnapshotQuery(ctx context.Context, session *bun.DB) IValidatorSnapshotQuery {
+	return &ValidatorSnapshotQuery{ctx: ctx, session: session}
+}
+
+// Query the last snapshot of each bucket of the interval within [from, to), in ascending order of time
+func (q *ValidatorSnapshotQuery) QueryValidatorHistory(consensusAddress string, from time.Time, to time.Time, interval models.ValidatorHistoryInterval) ([]models.ValidatorSnapshot, error) {
+	bucketExpr := q.session.Formatter().FormatQuery("date_trunc(?, validator_snapshot.timestamp)", interval.TruncUnit())
+
+	snapshots := make([]models.ValidatorSnapshot, 0)
+	err := q.session.NewSelect().
+		Model(&snapshots).
+		DistinctOn(bucketExpr).
+		ColumnExpr("validator_snapshot.*").
+		ColumnExpr(bucketExpr+" AS bucket").
+		Where("validator_snapshot.consensus_address = ?", consensusAddress).
+		Where("validator_snapshot.timestamp >= ?", from.UTC()).
+		Where("validator_snapshot.timestamp < ?", to.UTC()).
+		OrderExpr(bucketExpr + " ASC").
+		OrderExpr("validator_snapshot.timestamp DESC").
+		Scan(q.ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return snapshots, nil
+}
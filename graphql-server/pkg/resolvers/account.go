@@ -0,0 +1,101 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/forbole/bdjuno/database/types"
+	pkgContext "github.com/oursky/likedao/pkg/context"
+	servererrors "github.com/oursky/likedao/pkg/errors"
+	graphql1 "github.com/oursky/likedao/pkg/generated/graphql"
+	"github.com/oursky/likedao/pkg/models"
+)
+
+func (r *accountResolver) Balances(ctx context.Context, obj *models.Account) ([]types.DbDecCoin, error) {
+	if obj.Balance == nil {
+		return []types.DbDecCoin{}, nil
+	}
+
+	return toCoins(obj.Balance.Coins), nil
+}
+
+func (r *accountResolver) Rewards(ctx context.Context, obj *models.Account) ([]types.DbDecCoin, error) {
+	rewards := make([]types.DbDecCoins, 0, len(obj.DelegationRewards))
+	for _, reward := range obj.DelegationRewards {
+		rewards = append(rewards, reward.Amount)
+	}
+
+	return toCoins(sumCoins(rewards...)), nil
+}
+
+func (r *delegationResolver) Validator(ctx context.Context, obj *models.Delegation) (*models.Validator, error) {
+	// Fields of validator are loaded by dataloader
+	return &models.Validator{ConsensusAddress: obj.ValidatorAddress}, nil
+}
+
+func (r *delegationResolver) Amount(ctx context.Context, obj *models.Delegation) (*types.DbDecCoin, error) {
+	amount := toCoin(obj.Amount)
+	return &amount, nil
+}
+
+func (r *delegationResolver) Rewards(ctx context.Context, obj *models.Delegation) ([]types.DbDecCoin, error) {
+	if obj.Reward == nil {
+		return []types.DbDecCoin{}, nil
+	}
+
+	return toCoins(obj.Reward.Amount), nil
+}
+
+func (r *queryResolver) Account(ctx context.Context, address string) (*models.Account, error) {
+	config := pkgContext.GetConfigFromCtx(ctx)
+	prefix, _, err := bech32.DecodeAndConvert(address)
+	if err != nil || prefix != config.Chain.Bech32Prefix {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid account address: %s", address))
+	}
+
+	account, err := pkgContext.GetDataLoadersFromCtx(ctx).Account.LoadAccountByAddress(address)
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query account: %v", err))
+	}
+
+	return account, nil
+}
+
+func (r *redelegationResolver) SourceValidator(ctx context.Context, obj *models.Redelegation) (*models.Validator, error) {
+	return &models.Validator{ConsensusAddress: obj.SrcValidatorAddress}, nil
+}
+
+func (r *redelegationResolver) DestinationValidator(ctx context.Context, obj *models.Redelegation) (*models.Validator, error) {
+	return &models.Validator{ConsensusAddress: obj.DstValidatorAddress}, nil
+}
+
+func (r *unbondingDelegationResolver) Validator(ctx context.Context, obj *models.UnbondingDelegation) (*models.Validator, error) {
+	return &models.Validator{ConsensusAddress: obj.ValidatorAddress}, nil
+}
+
+// Account returns graphql1.AccountResolver implementation.
+func (r *Resolver) Account() graphql1.AccountResolver { return &accountResolver{r} }
+
+// Delegation returns graphql1.DelegationResolver implementation.
+func (r *Resolver) Delegation() graphql1.DelegationResolver { return &delegationResolver{r} }
+
+// Query returns graphql1.QueryResolver implementation.
+func (r *Resolver) Query() graphql1.QueryResolver { return &queryResolver{r} }
+
+// Redelegation returns graphql1.RedelegationResolver implementation.
+func (r *Resolver) Redelegation() graphql1.RedelegationResolver { return &redelegationResolver{r} }
+
+// UnbondingDelegation returns graphql1.UnbondingDelegationResolver implementation.
+func (r *Resolver) UnbondingDelegation() graphql1.UnbondingDelegationResolver {
+	return &unbondingDelegationResolver{r}
+}
+
+type accountResolver struct{ *Resolver }
+type delegationResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
+type redelegationResolver struct{ *Resolver }
+type unbondingDelegationResolver struct{ *Resolver }
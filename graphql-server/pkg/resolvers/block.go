@@ -5,14 +5,36 @@ package resolvers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	pkgContext "github.com/oursky/likedao/pkg/context"
 	servererrors "github.com/oursky/likedao/pkg/errors"
 	graphql1 "github.com/oursky/likedao/pkg/generated/graphql"
 	"github.com/oursky/likedao/pkg/models"
+	"github.com/oursky/likedao/pkg/queries"
 )
 
+func (r *blockResolver) Proposer(ctx context.Context, obj *models.Block) (*models.Validator, error) {
+	if obj.ProposerAddress == "" {
+		return nil, nil
+	}
+
+	validator, err := pkgContext.GetDataLoadersFromCtx(ctx).Validator.LoadValidatorWithInfoByConsensusAddress(obj.ProposerAddress)
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query block proposer: %v", err))
+	}
+	return validator, nil
+}
+
+func (r *blockResolver) Transactions(ctx context.Context, obj *models.Block) ([]models.Transaction, error) {
+	transactions, err := pkgContext.GetDataLoadersFromCtx(ctx).Transaction.LoadTransactionsByHeight(int64(obj.Height))
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query block transactions: %v", err))
+	}
+	return transactions, nil
+}
+
 func (r *queryResolver) LatestBlock(ctx context.Context) (*models.Block, error) {
 	res, err := pkgContext.GetQueriesFromCtx(ctx).Block.QueryLatestBlock()
 	if err != nil {
@@ -42,7 +64,52 @@ func (r *queryResolver) BlocksByIDs(ctx context.Context, ids []models.NodeID) ([
 	return res, nil
 }
 
-// Query returns graphql1.QueryResolver implementation.
-func (r *Resolver) Query() graphql1.QueryResolver { return &queryResolver{r} }
+func (r *queryResolver) BlockByHeight(ctx context.Context, height int) (*models.Block, error) {
+	res, err := pkgContext.GetDataLoadersFromCtx(ctx).Block.LoadBlockByHeight(int64(height))
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query block: %v", err))
+	}
+	return res, nil
+}
+
+func (r *queryResolver) Blocks(ctx context.Context, input models.QueryBlocksInput) (*models.Connection[models.Block], error) {
+	if (input.First != nil && *input.First > models.MaxBlocksPageSize) || (input.Last != nil && *input.Last > models.MaxBlocksPageSize) {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid pagination: at most %d blocks can be requested", models.MaxBlocksPageSize))
+	}
+
+	pagination, err := queries.NewPagination(input.First, input.After, input.Last, input.Before)
+	if err != nil {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid pagination: %v", err))
+	}
+
+	res, err := pkgContext.GetQueriesFromCtx(ctx).Block.
+		ScopeBlockHeightRange(input.MinHeight, input.MaxHeight).
+		ScopeBlockTimeRange(input.StartTime, input.EndTime).
+		QueryPaginatedBlocks(pagination)
+	if errors.Is(err, queries.ErrInvalidCursor) {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid pagination: %v", err))
+	}
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query blocks: %v", err))
+	}
+
+	conn := models.NewConnection(res.Items, res.Cursors)
+	conn.TotalCount = res.PaginationInfo.TotalCount
+	conn.PageInfo.HasNextPage = res.PaginationInfo.HasNext
+	conn.PageInfo.HasPreviousPage = res.PaginationInfo.HasPrevious
+
+	return &conn, nil
+}
+
+func (r *subscriptionResolver) LatestBlock(ctx context.Context) (<-chan *models.Block, error) {
+	return r.latestBlocks.Subscribe(ctx, struct{}{}), nil
+}
+
+// Block returns graphql1.BlockResolver implementation.
+func (r *Resolver) Block() graphql1.BlockResolver { return &blockResolver{r} }
+
+// Subscription returns graphql1.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() graphql1.SubscriptionResolver { return &subscriptionResolver{r} }
 
-type queryResolver struct{ *Resolver }
+type blockResolver struct{ *Resolver }
+type subscriptionResolver struct{ *Resolver }
@@ -81,3 +81,76 @@ func (r *queryResolver) CommunityStatus(ctx context.Context) (*models.CommunityS
 		BondedRatio:   fmt.Sprintf("%f", roundedBoundRatio),
 	}, nil
 }
+
+func (r *queryResolver) ChainParameters(ctx context.Context) (*models.ChainParameters, error) {
+	queryContext := pkgContext.GetQueriesFromCtx(ctx)
+
+	govParams, err := queryContext.Gov.QueryGovParams()
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query gov params: %v", err))
+	}
+
+	stakingParams, err := queryContext.Chain.QueryStakingParams()
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query staking params: %v", err))
+	}
+
+	slashingParams, err := queryContext.Chain.QuerySlashingParams()
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query slashing params: %v", err))
+	}
+
+	mintParams, err := queryContext.Chain.QueryMintParams()
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query mint params: %v", err))
+	}
+
+	distributionParams, err := queryContext.Chain.QueryDistributionParams()
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query distribution params: %v", err))
+	}
+
+	return &models.ChainParameters{
+		Gov: &models.GovParameters{
+			MinDeposit:       toCoinList(govParams.DepositParams.MinDeposit),
+			MaxDepositPeriod: int(govParams.DepositParams.MaxDepositPeriod.Seconds()),
+			VotingPeriod:     int(govParams.VotingParams.VotingPeriod.Seconds()),
+			Quorum:           govParams.TallyParams.Quorum,
+			Threshold:        govParams.TallyParams.Threshold,
+			VetoThreshold:    govParams.TallyParams.VetoThreshold,
+			Height:           int(govParams.Height),
+		},
+		Staking: &models.StakingParameters{
+			UnbondingTime:     int(stakingParams.Params.UnbondingTime.Seconds()),
+			MaxValidators:     stakingParams.Params.MaxValidators,
+			MaxEntries:        stakingParams.Params.MaxEntries,
+			HistoricalEntries: stakingParams.Params.HistoricalEntries,
+			BondDenom:         stakingParams.Params.BondDenom,
+			Height:            int(stakingParams.Height),
+		},
+		Slashing: &models.SlashingParameters{
+			SignedBlocksWindow:      int(slashingParams.Params.SignedBlocksWindow),
+			MinSignedPerWindow:      slashingParams.Params.MinSignedPerWindow,
+			DowntimeJailDuration:    int(slashingParams.Params.DowntimeJailDuration.Seconds()),
+			SlashFractionDoubleSign: slashingParams.Params.SlashFractionDoubleSign,
+			SlashFractionDowntime:   slashingParams.Params.SlashFractionDowntime,
+			Height:                  int(slashingParams.Height),
+		},
+		Mint: &models.MintParameters{
+			MintDenom:           mintParams.Params.MintDenom,
+			InflationRateChange: mintParams.Params.InflationRateChange,
+			InflationMax:        mintParams.Params.InflationMax,
+			InflationMin:        mintParams.Params.InflationMin,
+			GoalBonded:          mintParams.Params.GoalBonded,
+			BlocksPerYear:       models.BigInt(strconv.FormatUint(mintParams.Params.BlocksPerYear, 10)),
+			Height:              int(mintParams.Height),
+		},
+		Distribution: &models.DistributionParameters{
+			CommunityTax:        distributionParams.Params.CommunityTax,
+			BaseProposerReward:  distributionParams.Params.BaseProposerReward,
+			BonusProposerReward: distributionParams.Params.BonusProposerReward,
+			WithdrawAddrEnabled: distributionParams.Params.WithdrawAddrEnabled,
+			Height:              int(distributionParams.Height),
+		},
+	}, nil
+}
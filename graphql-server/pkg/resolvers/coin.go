@@ -0,0 +1,41 @@
+package resolvers
+
+import (
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	bdjuno "github.com/forbole/bdjuno/database/types"
+)
+
+// toCoins converts coins to the Coin type, values after decimal point are discarded as amount is an integer
+func toCoins(coins bdjuno.DbDecCoins) []bdjuno.DbDecCoin {
+	result := make([]bdjuno.DbDecCoin, 0, len(coins))
+	for _, coin := range coins {
+		result = append(result, toCoin(*coin))
+	}
+	return result
+}
+
+func toCoinList(coins []bdjuno.DbDecCoin) []bdjuno.DbDecCoin {
+	result := make([]bdjuno.DbDecCoin, 0, len(coins))
+	for _, coin := range coins {
+		result = append(result, toCoin(coin))
+	}
+	return result
+}
+
+func toCoin(coin bdjuno.DbDecCoin) bdjuno.DbDecCoin {
+	return bdjuno.DbDecCoin{
+		Denom:  coin.Denom,
+		Amount: strings.Split(coin.Amount, ".")[0],
+	}
+}
+
+// sumCoins adds up coins of the same denom
+func sumCoins(coinsList ...bdjuno.DbDecCoins) bdjuno.DbDecCoins {
+	sum := sdk.NewDecCoins()
+	for _, coins := range coinsList {
+		sum = sum.Add(coins.ToDecCoins()...)
+	}
+	return bdjuno.NewDbDecCoins(sum)
+}
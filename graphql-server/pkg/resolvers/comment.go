@@ -0,0 +1,202 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	pkgContext "github.com/oursky/likedao/pkg/context"
+	"github.com/oursky/likedao/pkg/dataloaders"
+	servererrors "github.com/oursky/likedao/pkg/errors"
+	graphql1 "github.com/oursky/likedao/pkg/generated/graphql"
+	"github.com/oursky/likedao/pkg/models"
+	"github.com/oursky/likedao/pkg/queries"
+)
+
+func (r *commentResolver) Proposal(ctx context.Context, obj *models.Comment) (*models.Proposal, error) {
+	proposal, err := pkgContext.GetDataLoadersFromCtx(ctx).Proposal.Load(strconv.Itoa(obj.ProposalID))
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query proposal: %v", err))
+	}
+	return proposal, nil
+}
+
+func (r *commentResolver) Parent(ctx context.Context, obj *models.Comment) (*models.Comment, error) {
+	if obj.ParentID == nil {
+		return nil, nil
+	}
+
+	parent, err := pkgContext.GetDataLoadersFromCtx(ctx).Comment.Load(*obj.ParentID)
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query parent comment: %v", err))
+	}
+	return parent, nil
+}
+
+func (r *commentResolver) Body(ctx context.Context, obj *models.Comment) (*string, error) {
+	if obj.IsDeleted() {
+		return nil, nil
+	}
+	return &obj.Body, nil
+}
+
+func (r *commentResolver) Reactions(ctx context.Context, obj *models.Comment) ([]models.ReactionCount, error) {
+	reactionCounts, err := pkgContext.GetDataLoadersFromCtx(ctx).Reaction.LoadTargetReactionCount(dataloaders.TargetReactionKey{
+		TargetType: models.ReactionTargetTypeComment,
+		TargetID:   obj.ID,
+	})
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query comment reactions: %v", err))
+	}
+
+	result := make([]models.ReactionCount, 0, len(reactionCounts))
+	for _, reactionCount := range reactionCounts {
+		result = append(result, models.ReactionCount{
+			TargetType: models.ReactionTargetTypeComment,
+			TargetID:   obj.ID,
+			Reaction:   reactionCount.Reaction,
+			Count:      reactionCount.Count,
+		})
+	}
+
+	return result, nil
+}
+
+func (r *commentResolver) MyReaction(ctx context.Context, obj *models.Comment) (*string, error) {
+	userAddress := pkgContext.GetAuthedUserAddress(ctx)
+	if userAddress == "" {
+		return nil, nil
+	}
+
+	reaction, err := pkgContext.GetDataLoadersFromCtx(ctx).Reaction.LoadUserTargetReactions(dataloaders.UserTargetReactionKey{
+		TargetReactionKey: dataloaders.TargetReactionKey{
+			TargetType: models.ReactionTargetTypeComment,
+			TargetID:   obj.ID,
+		},
+		UserAddress: userAddress,
+	})
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query comment reaction: %v", err))
+	}
+
+	if reaction == nil {
+		return nil, nil
+	}
+
+	return &reaction.Reaction, nil
+}
+
+func (r *commentResolver) Replies(ctx context.Context, obj *models.Comment, input models.QueryCommentsInput) (*models.Connection[models.Comment], error) {
+	pagination, err := queries.NewPagination(input.First, input.After, input.Last, input.Before)
+	if err != nil {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid pagination: %v", err))
+	}
+
+	res, err := pkgContext.GetQueriesFromCtx(ctx).Comment.ScopeParent(obj.ID).QueryPaginatedComments(pagination)
+	if errors.Is(err, queries.ErrInvalidCursor) {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid pagination: %v", err))
+	}
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query replies: %v", err))
+	}
+
+	conn := models.NewConnection(res.Items, res.Cursors)
+	conn.TotalCount = res.PaginationInfo.TotalCount
+	conn.PageInfo.HasNextPage = res.PaginationInfo.HasNext
+	conn.PageInfo.HasPreviousPage = res.PaginationInfo.HasPrevious
+
+	return &conn, nil
+}
+
+func (r *mutationResolver) CreateComment(ctx context.Context, input models.CreateCommentInput) (*models.Comment, error) {
+	userAddress := pkgContext.GetAuthedUserAddress(ctx)
+	dataLoaders := pkgContext.GetDataLoadersFromCtx(ctx)
+
+	body, err := models.NormalizeCommentBody(input.Body)
+	if err != nil {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid comment: %v", err))
+	}
+
+	if input.ProposalID.EntityType != "proposal" {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid proposal id: %s", input.ProposalID))
+	}
+	proposalID, err := strconv.Atoi(input.ProposalID.ID)
+	if err != nil {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid proposal id: %v", err))
+	}
+	proposal, err := dataLoaders.Proposal.Load(input.ProposalID.ID)
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query proposal: %v", err))
+	}
+	if proposal == nil {
+		return nil, servererrors.NotFound.NewError(ctx, fmt.Sprintf("proposal %d not found", proposalID))
+	}
+
+	var parentID *string
+	if input.ParentID != nil {
+		if input.ParentID.EntityType != "comment" {
+			return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid parent id: %s", *input.ParentID))
+		}
+		parent, err := dataLoaders.Comment.Load(input.ParentID.ID)
+		if err != nil {
+			return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query parent comment: %v", err))
+		}
+		if parent == nil || parent.ProposalID != proposalID {
+			return nil, servererrors.NotFound.NewError(ctx, fmt.Sprintf("comment %s not found in proposal %d", input.ParentID.ID, proposalID))
+		}
+		if parent.IsDeleted() {
+			return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("comment %s is deleted", parent.ID))
+		}
+		parentID = &parent.ID
+	}
+
+	comment, err := pkgContext.GetMutatorsFromCtx(ctx).Comment.CreateComment(proposalID, parentID, userAddress, body)
+	if err != nil {
+		return nil, servererrors.MutationError.NewError(ctx, fmt.Sprintf("failed to create comment: %v", err))
+	}
+	return comment, nil
+}
+
+func (r *mutationResolver) UpdateComment(ctx context.Context, input models.UpdateCommentInput) (*models.Comment, error) {
+	userAddress := pkgContext.GetAuthedUserAddress(ctx)
+	if input.CommentID.EntityType != "comment" {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid comment id: %s", input.CommentID))
+	}
+
+	body, err := models.NormalizeCommentBody(input.Body)
+	if err != nil {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid comment: %v", err))
+	}
+
+	comment, err := pkgContext.GetMutatorsFromCtx(ctx).Comment.UpdateComment(input.CommentID.ID, userAddress, body)
+	if err != nil {
+		return nil, servererrors.MutationError.NewError(ctx, fmt.Sprintf("failed to update comment: %v", err))
+	}
+	return comment, nil
+}
+
+func (r *mutationResolver) DeleteComment(ctx context.Context, input models.DeleteCommentInput) (*models.Comment, error) {
+	userAddress := pkgContext.GetAuthedUserAddress(ctx)
+	if input.CommentID.EntityType != "comment" {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid comment id: %s", input.CommentID))
+	}
+
+	comment, err := pkgContext.GetMutatorsFromCtx(ctx).Comment.DeleteComment(input.CommentID.ID, userAddress)
+	if err != nil {
+		return nil, servererrors.MutationError.NewError(ctx, fmt.Sprintf("failed to delete comment: %v", err))
+	}
+	return comment, nil
+}
+
+// Comment returns graphql1.CommentResolver implementation.
+func (r *Resolver) Comment() graphql1.CommentResolver { return &commentResolver{r} }
+
+// Mutation returns graphql1.MutationResolver implementation.
+func (r *Resolver) Mutation() graphql1.MutationResolver { return &mutationResolver{r} }
+
+type commentResolver struct{ *Resolver }
+type mutationResolver struct{ *Resolver }
@@ -0,0 +1,17 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// isFieldRequested checks if the field is selected on the result of the current resolver
+func isFieldRequested(ctx context.Context, name string) bool {
+	for _, field := range graphql.CollectAllFields(ctx) {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
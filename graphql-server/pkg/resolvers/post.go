@@ -0,0 +1,79 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	pkgContext "github.com/oursky/likedao/pkg/context"
+	"github.com/oursky/likedao/pkg/dataloaders"
+	servererrors "github.com/oursky/likedao/pkg/errors"
+	graphql1 "github.com/oursky/likedao/pkg/generated/graphql"
+	"github.com/oursky/likedao/pkg/models"
+)
+
+func (r *postResolver) Proposal(ctx context.Context, obj *models.Post) (*models.Proposal, error) {
+	if obj.ProposalID == nil {
+		return nil, nil
+	}
+
+	proposal, err := pkgContext.GetDataLoadersFromCtx(ctx).Proposal.Load(strconv.Itoa(*obj.ProposalID))
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query proposal: %v", err))
+	}
+	return proposal, nil
+}
+
+func (r *postResolver) Reactions(ctx context.Context, obj *models.Post) ([]models.ReactionCount, error) {
+	reactionCounts, err := pkgContext.GetDataLoadersFromCtx(ctx).Reaction.LoadTargetReactionCount(dataloaders.TargetReactionKey{
+		TargetType: models.ReactionTargetTypePost,
+		TargetID:   obj.ISCNID,
+	})
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query post reactions: %v", err))
+	}
+
+	result := make([]models.ReactionCount, 0, len(reactionCounts))
+	for _, reactionCount := range reactionCounts {
+		result = append(result, models.ReactionCount{
+			TargetType: models.ReactionTargetTypePost,
+			TargetID:   obj.ISCNID,
+			Reaction:   reactionCount.Reaction,
+			Count:      reactionCount.Count,
+		})
+	}
+
+	return result, nil
+}
+
+func (r *postResolver) MyReaction(ctx context.Context, obj *models.Post) (*string, error) {
+	userAddress := pkgContext.GetAuthedUserAddress(ctx)
+	if userAddress == "" {
+		return nil, nil
+	}
+
+	reaction, err := pkgContext.GetDataLoadersFromCtx(ctx).Reaction.LoadUserTargetReactions(dataloaders.UserTargetReactionKey{
+		TargetReactionKey: dataloaders.TargetReactionKey{
+			TargetType: models.ReactionTargetTypePost,
+			TargetID:   obj.ISCNID,
+		},
+		UserAddress: userAddress,
+	})
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query post reaction: %v", err))
+	}
+
+	if reaction == nil {
+		return nil, nil
+	}
+
+	return &reaction.Reaction, nil
+}
+
+// Post returns graphql1.PostResolver implementation.
+func (r *Resolver) Post() graphql1.PostResolver { return &postResolver{r} }
+
+type postResolver struct{ *Resolver }
@@ -5,17 +5,33 @@ package resolvers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/forbole/bdjuno/database/types"
 	pkgContext "github.com/oursky/likedao/pkg/context"
 	"github.com/oursky/likedao/pkg/dataloaders"
 	servererrors "github.com/oursky/likedao/pkg/errors"
 	graphql1 "github.com/oursky/likedao/pkg/generated/graphql"
 	"github.com/oursky/likedao/pkg/models"
+	"github.com/oursky/likedao/pkg/queries"
 )
 
+func (r *communityPoolSpendProposalContentResolver) Amount(ctx context.Context, obj *models.CommunityPoolSpendProposalContent) ([]types.DbDecCoin, error) {
+	return toCoinList(obj.Amount), nil
+}
+
+func (r *parameterChangeResolver) CurrentValue(ctx context.Context, obj *models.ParameterChange) (*string, error) {
+	value, err := pkgContext.GetDataLoadersFromCtx(ctx).Chain.LoadParamValue(models.ParamKey{Subspace: obj.Subspace, Key: obj.Key})
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query param value: %v", err))
+	}
+	return value, nil
+}
+
 func (r *proposalResolver) ProposalID(ctx context.Context, obj *models.Proposal) (int, error) {
 	return obj.ID, nil
 }
@@ -62,6 +78,43 @@ func (r *proposalResolver) Turnout(ctx context.Context, obj *models.Proposal) (*
 	return turnout, nil
 }
 
+func (r *proposalResolver) VotingPowerBreakdown(ctx context.Context, obj *models.Proposal) (*models.ProposalVotingPowerBreakdown, error) {
+	breakdown, err := pkgContext.GetDataLoadersFromCtx(ctx).Proposal.LoadProposalVotingPowerBreakdown(obj.ID)
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query voting power breakdown: %v", err))
+	}
+	return breakdown, nil
+}
+
+func (r *proposalResolver) Outcome(ctx context.Context, obj *models.Proposal) (*models.ProposalOutcome, error) {
+	if obj.Status == models.ProposalStatusFailed || obj.Status == models.ProposalStatusInvalid || obj.Status == models.ProposalStatusDepositPeriod {
+		return nil, nil
+	}
+
+	tally, err := pkgContext.GetDataLoadersFromCtx(ctx).Proposal.LoadProposalTallyResult(obj.ID)
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to load proposal tally result: %v", err))
+	}
+	snapshot, err := pkgContext.GetDataLoadersFromCtx(ctx).Proposal.LoadProposalStakingPoolSnapshot(obj.ID)
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to load proposal staking pool snapshot: %v", err))
+	}
+	if tally == nil || snapshot == nil {
+		return nil, nil
+	}
+
+	params, err := pkgContext.GetDataLoadersFromCtx(ctx).Gov.LoadGovParams()
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to load gov params: %v", err))
+	}
+
+	outcome, err := models.NewProposalOutcome(*tally, snapshot.BondedTokens, params.TallyParams)
+	if err != nil {
+		return nil, servererrors.InternalError.NewError(ctx, fmt.Sprintf("failed to project proposal outcome: %v", err))
+	}
+	return outcome, nil
+}
+
 func (r *proposalResolver) VoteByAddress(ctx context.Context, obj *models.Proposal, address string) (*models.ProposalVote, error) {
 	key := models.ProposalVoteKey{ProposalID: obj.ID, Address: address}
 	vote, err := pkgContext.GetDataLoadersFromCtx(ctx).Proposal.LoadProposalVote(key)
@@ -80,6 +133,79 @@ func (r *proposalResolver) DepositByAddress(ctx context.Context, obj *models.Pro
 	return vote, nil
 }
 
+func (r *proposalResolver) MyGovernanceSummary(ctx context.Context, obj *models.Proposal) (*models.ProposalGovernanceSummary, error) {
+	userAddress := pkgContext.GetAuthedUserAddress(ctx)
+	if userAddress == "" {
+		return nil, nil
+	}
+
+	account, err := pkgContext.GetDataLoadersFromCtx(ctx).Account.LoadAccountByAddress(userAddress)
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query account: %v", err))
+	}
+	delegations := []models.Delegation{}
+	if account != nil {
+		delegations = account.Delegations
+	}
+
+	validatorAddresses := make([]string, 0, len(delegations))
+	for _, delegation := range delegations {
+		validatorAddresses = append(validatorAddresses, delegation.ValidatorAddress)
+	}
+	validators, errs := pkgContext.GetDataLoadersFromCtx(ctx).Validator.LoadValidatorsWithInfoByConsensusAddresses(validatorAddresses)
+	for _, err := range errs {
+		if err != nil {
+			return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query validators: %v", err))
+		}
+	}
+
+	// The vote of the user is loaded together with votes of the validators, which are batched across proposals
+	voteKeys := []models.ProposalVoteKey{{ProposalID: obj.ID, Address: userAddress}}
+	for _, validator := range validators {
+		if validator != nil && validator.Info != nil && validator.Info.SelfDelegateAddress != "" {
+			voteKeys = append(voteKeys, models.ProposalVoteKey{ProposalID: obj.ID, Address: validator.Info.SelfDelegateAddress})
+		}
+	}
+	votes, errs := pkgContext.GetDataLoadersFromCtx(ctx).Proposal.LoadProposalVotes(voteKeys)
+	for _, err := range errs {
+		if err != nil {
+			return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query proposal votes: %v", err))
+		}
+	}
+	addressToVote := make(map[string]*models.ProposalVote, len(votes))
+	for i, vote := range votes {
+		if vote != nil {
+			addressToVote[voteKeys[i].Address] = vote
+		}
+	}
+	userVote := addressToVote[userAddress]
+
+	delegationVotes := make([]models.ProposalDelegationVote, 0, len(delegations))
+	for i, delegation := range delegations {
+		delegationVote := models.ProposalDelegationVote{
+			ValidatorAddress: delegation.ValidatorAddress,
+			Amount:           delegation.Amount,
+			DelegatorVote:    userVote,
+		}
+
+		validator := validators[i]
+		if validator != nil {
+			delegationVote.Tallied = validator.Status != nil && validator.Status.Status == int(stakingtypes.Bonded)
+			if validator.Info != nil && validator.Info.SelfDelegateAddress != "" {
+				delegationVote.ValidatorVote = addressToVote[validator.Info.SelfDelegateAddress]
+			}
+		}
+
+		delegationVotes = append(delegationVotes, delegationVote)
+	}
+
+	summary, err := models.NewProposalGovernanceSummary(userVote, delegationVotes)
+	if err != nil {
+		return nil, servererrors.InternalError.NewError(ctx, fmt.Sprintf("failed to summarize delegation votes: %v", err))
+	}
+	return summary, nil
+}
+
 func (r *proposalResolver) Reactions(ctx context.Context, obj *models.Proposal) ([]models.ReactionCount, error) {
 	reactionCounts, err := pkgContext.GetDataLoadersFromCtx(ctx).Reaction.LoadProposalReactionCount(obj.ID)
 	if err != nil {
@@ -88,7 +214,12 @@ func (r *proposalResolver) Reactions(ctx context.Context, obj *models.Proposal)
 
 	var result []models.ReactionCount
 	for _, reactionCount := range reactionCounts {
-		result = append(result, models.ReactionCount{Reaction: reactionCount.Reaction, Count: reactionCount.Count})
+		result = append(result, models.ReactionCount{
+			TargetType: models.ReactionTargetTypeProposal,
+			TargetID:   strconv.Itoa(obj.ID),
+			Reaction:   reactionCount.Reaction,
+			Count:      reactionCount.Count,
+		})
 
 	}
 
@@ -116,202 +247,214 @@ func (r *proposalResolver) MyReaction(ctx context.Context, obj *models.Proposal)
 	return &reaction.Reaction, nil
 }
 
-func (r *proposalResolver) Votes(ctx context.Context, obj *models.Proposal, input models.QueryProposalVotesInput) (*models.Connection[models.ProposalVote], error) {
-	result := make([]models.ProposalVote, 0)
+func (r *proposalResolver) ReactionTimeline(ctx context.Context, obj *models.Proposal, buckets int) ([]models.ReactionTimelineBucket, error) {
+	if buckets < 1 || buckets > models.MaxReactionTimelineBuckets {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid buckets: must be between 1 and %d", models.MaxReactionTimelineBuckets))
+	}
 
-	validatorLimit := input.First
-	validatorOffset := input.After
-	// No need validators if no delegation or already went through all the validators
-	if len(input.PinnedValidators) == 0 || input.After > len(input.PinnedValidators) {
-		validatorLimit = 0
-		validatorOffset = 0
+	// Timeline of an ongoing voting period ends now
+	from, to := obj.VotingStartTime, obj.VotingEndTime
+	if now := time.Now(); to.IsZero() || to.After(now) {
+		to = now
+	}
+	if from.IsZero() || !from.Before(to) {
+		return []models.ReactionTimelineBucket{}, nil
 	}
 
-	validators, err := pkgContext.GetQueriesFromCtx(ctx).Validator.WithProposalVotes().QueryPaginatedValidators(validatorLimit, validatorOffset, input.PinnedValidators)
+	timeline, err := pkgContext.GetQueriesFromCtx(ctx).Reaction.QueryReactionTimeline(models.ReactionTargetTypeProposal, strconv.Itoa(obj.ID), from, to, buckets)
 	if err != nil {
-		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to load validators: %v", err))
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query reaction timeline: %v", err))
 	}
+	return timeline, nil
+}
 
-	// Add validators to result
-	validatorDelegationAddresses := make([]string, 0)
-	for i := range validators.Items {
-		validator := validators.Items[i]
-		// There should only be at most one vote when scoped
-		if validator.Info != nil && len(validator.Info.ProposalVotes) != 0 {
-			for _, vote := range validator.Info.ProposalVotes {
-				if vote.ProposalID == obj.ID {
-					result = append(result, vote)
-					break
-				}
-			}
-		} else {
-			result = append(result, models.ProposalVote{
+func (r *proposalResolver) Votes(ctx context.Context, obj *models.Proposal, input models.QueryProposalVotesInput) (*models.Connection[models.ProposalVote], error) {
+	pagination, err := queries.NewOffsetPagination(input.First, input.After, input.Last, input.Before, input.Offset)
+	if err != nil {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid pagination: %v", err))
+	}
+
+	queryContext := pkgContext.GetQueriesFromCtx(ctx)
+
+	// Pinned validators come first, with their votes or placeholders if they have not voted
+	validatorSection := func(pagination queries.Pagination) (*queries.Paginated[models.ProposalVote], error) {
+		if len(input.PinnedValidators) == 0 {
+			return &queries.Paginated[models.ProposalVote]{}, nil
+		}
+
+		validators, err := queryContext.Validator.WithProposalVotes().QueryPaginatedValidators(pagination, input.PinnedValidators)
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]models.ProposalVote, 0, len(validators.Items))
+		for i := range validators.Items {
+			validator := validators.Items[i]
+			vote := models.ProposalVote{
 				ProposalID:   obj.ID,
 				VoterAddress: validator.Info.SelfDelegateAddress,
 				Option:       "",
 				Height:       validator.Info.Height,
 
 				ValidatorInfo: validator.Info,
-			})
+			}
+			// There should only be at most one vote when scoped
+			for _, validatorVote := range validator.Info.ProposalVotes {
+				if validatorVote.ProposalID == obj.ID {
+					vote = validatorVote
+					break
+				}
+			}
+			result = append(result, vote)
 		}
 
-		validatorDelegationAddresses = append(validatorDelegationAddresses, validator.Info.SelfDelegateAddress)
-	}
-
-	voteLimit := input.First
-	// Get the remaining vote items if the number of validators are fewer than the pageSize
-	if len(validators.Items) < input.First {
-		voteLimit = input.First - len(validators.Items)
+		return &queries.Paginated[models.ProposalVote]{
+			Items:          result,
+			Cursors:        validators.Cursors,
+			PaginationInfo: validators.PaginationInfo,
+		}, nil
 	}
 
-	// Skip votes if we already have enough items for a page
-	if len(validators.Items) == input.First {
-		voteLimit = 0
+	voteSection := func(pagination queries.Pagination) (*queries.Paginated[models.ProposalVote], error) {
+		return queryContext.Proposal.QueryPaginatedProposalVotes(obj.ID, pagination, input.Order, input.PinnedValidators)
 	}
 
-	// Start offsetting the votes if we are done with the validators
-	voteOffset := input.After
-	if len(input.PinnedValidators) != 0 {
-		voteOffset -= validators.PaginationInfo.TotalCount
-	}
-	if voteOffset < 0 {
-		voteOffset = 0
+	res, err := queries.PaginateSections(pagination, validatorSection, voteSection)
+	if errors.Is(err, queries.ErrInvalidCursor) {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid pagination: %v", err))
 	}
-
-	votes, err := pkgContext.GetQueriesFromCtx(ctx).Proposal.QueryPaginatedProposalVotes(obj.ID, voteLimit, voteOffset, input.Order, validatorDelegationAddresses)
 	if err != nil {
 		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to load proposal votes: %v", err))
 	}
 
-	// Add votes to result
-	for i := range votes.Items {
-		vote := votes.Items[i]
-		result = append(result, vote)
-	}
-
-	voteWithValidatorCursorMap := make(map[string]string)
-	for index, identity := range result {
-		cursorString := strconv.Itoa(input.After + index + 1)
-		voteWithValidatorCursorMap[identity.NodeID().String()] = cursorString
-
-	}
-
-	conn := models.NewConnection(result, func(model models.ProposalVote) string {
-		return voteWithValidatorCursorMap[model.NodeID().String()]
-	})
-
-	totalCount := votes.PaginationInfo.TotalCount
-	hasNextPage := votes.PaginationInfo.HasNext
-	hasPreviousPage := votes.PaginationInfo.HasPrevious
-	if validatorLimit != 0 {
-		totalCount = totalCount + validators.PaginationInfo.TotalCount
-		hasNextPage = validators.PaginationInfo.HasNext
-		hasPreviousPage = validators.PaginationInfo.HasPrevious
-	}
-
-	conn.TotalCount = totalCount
-	conn.PageInfo.HasNextPage = hasNextPage
-	conn.PageInfo.HasPreviousPage = hasPreviousPage
+	conn := models.NewConnection(res.Items, res.Cursors)
+	conn.TotalCount = res.PaginationInfo.TotalCount
+	conn.PageInfo.HasNextPage = res.PaginationInfo.HasNext
+	conn.PageInfo.HasPreviousPage = res.PaginationInfo.HasPrevious
 
 	return &conn, nil
 }
 
 func (r *proposalResolver) Deposits(ctx context.Context, obj *models.Proposal, input models.QueryProposalDepositsInput) (*models.Connection[models.ProposalDeposit], error) {
-	result := make([]models.ProposalDeposit, 0)
-
-	validatorLimit := input.First
-	validatorOffset := input.After
-	// No need validators if no delegation or already went through all the validators
-	if len(input.PinnedValidators) == 0 || input.After > len(input.PinnedValidators) {
-		validatorLimit = 0
-		validatorOffset = 0
-	}
-
-	validators, err := pkgContext.GetQueriesFromCtx(ctx).Validator.WithProposalDeposits().QueryPaginatedValidators(validatorLimit, validatorOffset, input.PinnedValidators)
+	pagination, err := queries.NewOffsetPagination(input.First, input.After, input.Last, input.Before, input.Offset)
 	if err != nil {
-		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to load validators: %v", err))
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid pagination: %v", err))
 	}
 
-	// Add validators to result
-	for i := range validators.Items {
-		validator := validators.Items[i]
-		if validator.Info != nil && len(validator.Info.ProposalDeposits) != 0 {
-			for _, deposit := range validator.Info.ProposalDeposits {
-				if deposit.ProposalID == obj.ID {
-					result = append(result, deposit)
-					break
-				}
-			}
-		} else {
-			result = append(result, models.ProposalDeposit{
+	queryContext := pkgContext.GetQueriesFromCtx(ctx)
+
+	// Pinned validators come first, with their deposits or placeholders if they have not deposited
+	validatorSection := func(pagination queries.Pagination) (*queries.Paginated[models.ProposalDeposit], error) {
+		if len(input.PinnedValidators) == 0 {
+			return &queries.Paginated[models.ProposalDeposit]{}, nil
+		}
+
+		validators, err := queryContext.Validator.WithProposalDeposits().QueryPaginatedValidators(pagination, input.PinnedValidators)
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]models.ProposalDeposit, 0, len(validators.Items))
+		for i := range validators.Items {
+			validator := validators.Items[i]
+			deposit := models.ProposalDeposit{
 				ProposalID:       obj.ID,
 				DepositorAddress: validator.Info.SelfDelegateAddress,
 				Amount:           nil,
 				Height:           validator.Info.Height,
 
 				ValidatorInfo: validator.Info,
-			})
+			}
+			for _, validatorDeposit := range validator.Info.ProposalDeposits {
+				if validatorDeposit.ProposalID == obj.ID {
+					deposit = validatorDeposit
+					break
+				}
+			}
+			result = append(result, deposit)
 		}
-	}
 
-	depositLimit := input.First
-	// Get the remaining vote items if the number of validators are fewer than the pageSize
-	if len(validators.Items) < input.First {
-		depositLimit = input.First - len(validators.Items)
+		return &queries.Paginated[models.ProposalDeposit]{
+			Items:          result,
+			Cursors:        validators.Cursors,
+			PaginationInfo: validators.PaginationInfo,
+		}, nil
 	}
 
-	// Skip votes if we already have enough items for a page
-	if len(validators.Items) == input.First {
-		depositLimit = 0
+	depositSection := func(pagination queries.Pagination) (*queries.Paginated[models.ProposalDeposit], error) {
+		return queryContext.Proposal.QueryPaginatedProposalDeposits(obj.ID, pagination, input.Order, input.PinnedValidators)
 	}
 
-	// Start offsetting the votes if we are done with the validators
-	depositOffset := input.After
-	if len(input.PinnedValidators) != 0 {
-		depositOffset -= validators.PaginationInfo.TotalCount
+	res, err := queries.PaginateSections(pagination, validatorSection, depositSection)
+	if errors.Is(err, queries.ErrInvalidCursor) {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid pagination: %v", err))
 	}
-	if depositOffset < 0 {
-		depositOffset = 0
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to load proposal deposits: %v", err))
 	}
 
-	deposits, err := pkgContext.GetQueriesFromCtx(ctx).Proposal.QueryPaginatedProposalDeposits(obj.ID, depositLimit, depositOffset, input.Order, input.PinnedValidators)
+	conn := models.NewConnection(res.Items, res.Cursors)
+	conn.TotalCount = res.PaginationInfo.TotalCount
+	conn.PageInfo.HasNextPage = res.PaginationInfo.HasNext
+	conn.PageInfo.HasPreviousPage = res.PaginationInfo.HasPrevious
+
+	return &conn, nil
+}
+
+func (r *proposalResolver) Comments(ctx context.Context, obj *models.Proposal, input models.QueryCommentsInput) (*models.Connection[models.Comment], error) {
+	pagination, err := queries.NewPagination(input.First, input.After, input.Last, input.Before)
 	if err != nil {
-		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to load proposal votes: %v", err))
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid pagination: %v", err))
 	}
 
-	// Add votes to result
-	for i := range deposits.Items {
-		deposit := deposits.Items[i]
-		result = append(result, deposit)
+	res, err := pkgContext.GetQueriesFromCtx(ctx).Comment.ScopeProposal(obj.ID).QueryPaginatedComments(pagination)
+	if errors.Is(err, queries.ErrInvalidCursor) {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid pagination: %v", err))
+	}
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query comments: %v", err))
 	}
 
-	depositWithValidatorCursorMap := make(map[string]string)
-	for index, identity := range result {
-		cursorString := strconv.Itoa(input.After + index + 1)
-		depositWithValidatorCursorMap[identity.NodeID().String()] = cursorString
+	conn := models.NewConnection(res.Items, res.Cursors)
+	conn.TotalCount = res.PaginationInfo.TotalCount
+	conn.PageInfo.HasNextPage = res.PaginationInfo.HasNext
+	conn.PageInfo.HasPreviousPage = res.PaginationInfo.HasPrevious
 
-	}
+	return &conn, nil
+}
 
-	conn := models.NewConnection(result, func(model models.ProposalDeposit) string {
-		return depositWithValidatorCursorMap[model.NodeID().String()]
-	})
+func (r *proposalResolver) Posts(ctx context.Context, obj *models.Proposal, input models.QueryPostsInput) (*models.Connection[models.Post], error) {
+	pagination, err := queries.NewPagination(input.First, input.After, input.Last, input.Before)
+	if err != nil {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid pagination: %v", err))
+	}
 
-	totalCount := deposits.PaginationInfo.TotalCount
-	hasNextPage := deposits.PaginationInfo.HasNext
-	hasPreviousPage := deposits.PaginationInfo.HasPrevious
-	if validatorLimit != 0 {
-		totalCount = totalCount + validators.PaginationInfo.TotalCount
-		hasNextPage = validators.PaginationInfo.HasNext
-		hasPreviousPage = validators.PaginationInfo.HasPrevious
+	res, err := pkgContext.GetQueriesFromCtx(ctx).Post.ScopeProposal(obj.ID).QueryPaginatedPosts(pagination)
+	if errors.Is(err, queries.ErrInvalidCursor) {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid pagination: %v", err))
+	}
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query posts: %v", err))
 	}
 
-	conn.TotalCount = totalCount
-	conn.PageInfo.HasNextPage = hasNextPage
-	conn.PageInfo.HasPreviousPage = hasPreviousPage
+	conn := models.NewConnection(res.Items, res.Cursors)
+	conn.TotalCount = res.PaginationInfo.TotalCount
+	conn.PageInfo.HasNextPage = res.PaginationInfo.HasNext
+	conn.PageInfo.HasPreviousPage = res.PaginationInfo.HasPrevious
 
 	return &conn, nil
 }
 
+func (r *proposalDelegationVoteResolver) Validator(ctx context.Context, obj *models.ProposalDelegationVote) (*models.Validator, error) {
+	// Fields of validator are loaded by dataloader
+	return &models.Validator{ConsensusAddress: obj.ValidatorAddress}, nil
+}
+
+func (r *proposalDelegationVoteResolver) Amount(ctx context.Context, obj *models.ProposalDelegationVote) (*types.DbDecCoin, error) {
+	amount := toCoin(obj.Amount)
+	return &amount, nil
+}
+
 func (r *proposalDepositResolver) Depositor(ctx context.Context, obj *models.ProposalDeposit) (models.ProposalDepositor, error) {
 	// Deposit is from a validator
 	validator, err := pkgContext.GetDataLoadersFromCtx(ctx).Validator.LoadValidatorWithInfoBySelfDelegationAddress(obj.DepositorAddress)
@@ -404,7 +547,30 @@ func (r *proposalVoteResolver) Option(ctx context.Context, obj *models.ProposalV
 	return &obj.Option, nil
 }
 
+func (r *proposalVoteResolver) VotingPower(ctx context.Context, obj *models.ProposalVote) (models.BigInt, error) {
+	if obj.VotingPower != nil {
+		return models.NewBigIntFromBunBigInt(*obj.VotingPower), nil
+	}
+
+	// Votes loaded without voting power are loaded again with it
+	key := models.ProposalVoteKey{ProposalID: obj.ProposalID, Address: obj.VoterAddress}
+	vote, err := pkgContext.GetDataLoadersFromCtx(ctx).Proposal.LoadProposalVote(key)
+	if err != nil {
+		return "", servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query proposal vote: %v", err))
+	}
+	if vote == nil || vote.VotingPower == nil {
+		return models.NewBigInt(0), nil
+	}
+
+	return models.NewBigIntFromBunBigInt(*vote.VotingPower), nil
+}
+
 func (r *queryResolver) Proposals(ctx context.Context, input models.QueryProposalsInput) (*models.Connection[models.Proposal], error) {
+	pagination, err := queries.NewOffsetPagination(input.First, input.After, input.Last, input.Before, input.Offset)
+	if err != nil {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid pagination: %v", err))
+	}
+
 	proposalQuery := pkgContext.GetQueriesFromCtx(ctx).Proposal
 	if input.Address != nil {
 		if !input.Address.IsDepositor && !input.Address.IsSubmitter && !input.Address.IsVoter {
@@ -423,19 +589,19 @@ func (r *queryResolver) Proposals(ctx context.Context, input models.QueryProposa
 		proposalQuery = proposalQuery.ScopeProposalOrder(input.Order)
 	}
 
-	res, err := proposalQuery.QueryPaginatedProposals(input.First, input.After)
+	if input.SearchTerm != nil && *input.SearchTerm != "" {
+		proposalQuery = proposalQuery.ScopeProposalSearchTerm(*input.SearchTerm)
+	}
+
+	res, err := proposalQuery.QueryPaginatedProposals(pagination)
+	if errors.Is(err, queries.ErrInvalidCursor) {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid pagination: %v", err))
+	}
 	if err != nil {
 		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to load proposals: %v", err))
 	}
-	proposalCursorMap := make(map[int]string)
-	for index, proposal := range res.Items {
-		cursorString := strconv.Itoa(input.After + index + 1)
-		proposalCursorMap[proposal.ID] = cursorString
-	}
 
-	conn := models.NewConnection(res.Items, func(model models.Proposal) string {
-		return proposalCursorMap[model.ID]
-	})
+	conn := models.NewConnection(res.Items, res.Cursors)
 	conn.TotalCount = res.PaginationInfo.TotalCount
 	conn.PageInfo.HasNextPage = res.PaginationInfo.HasNext
 	conn.PageInfo.HasPreviousPage = res.PaginationInfo.HasPrevious
@@ -459,9 +625,48 @@ func (r *queryResolver) ProposalVotesDistribution(ctx context.Context, address s
 	return distribution, nil
 }
 
+func (r *subscriptionResolver) ProposalTallyUpdated(ctx context.Context, id models.NodeID) (<-chan *models.ProposalTallyResult, error) {
+	proposal, err := pkgContext.GetDataLoadersFromCtx(ctx).Proposal.Load(id.ID)
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to load proposal: %v", err))
+	}
+	if proposal == nil {
+		return nil, servererrors.NotFound.NewError(ctx, fmt.Sprintf("proposal %s not found", id.ID))
+	}
+
+	return r.proposalTallies.Subscribe(ctx, proposal.ID), nil
+}
+
+func (r *subscriptionResolver) ProposalVoteAdded(ctx context.Context, id models.NodeID) (<-chan *models.ProposalVote, error) {
+	proposal, err := pkgContext.GetDataLoadersFromCtx(ctx).Proposal.Load(id.ID)
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to load proposal: %v", err))
+	}
+	if proposal == nil {
+		return nil, servererrors.NotFound.NewError(ctx, fmt.Sprintf("proposal %s not found", id.ID))
+	}
+
+	return r.proposalVotes.Subscribe(ctx, proposal.ID), nil
+}
+
+// CommunityPoolSpendProposalContent returns graphql1.CommunityPoolSpendProposalContentResolver implementation.
+func (r *Resolver) CommunityPoolSpendProposalContent() graphql1.CommunityPoolSpendProposalContentResolver {
+	return &communityPoolSpendProposalContentResolver{r}
+}
+
+// ParameterChange returns graphql1.ParameterChangeResolver implementation.
+func (r *Resolver) ParameterChange() graphql1.ParameterChangeResolver {
+	return &parameterChangeResolver{r}
+}
+
 // Proposal returns graphql1.ProposalResolver implementation.
 func (r *Resolver) Proposal() graphql1.ProposalResolver { return &proposalResolver{r} }
 
+// ProposalDelegationVote returns graphql1.ProposalDelegationVoteResolver implementation.
+func (r *Resolver) ProposalDelegationVote() graphql1.ProposalDelegationVoteResolver {
+	return &proposalDelegationVoteResolver{r}
+}
+
 // ProposalDeposit returns graphql1.ProposalDepositResolver implementation.
 func (r *Resolver) ProposalDeposit() graphql1.ProposalDepositResolver {
 	return &proposalDepositResolver{r}
@@ -475,7 +680,10 @@ func (r *Resolver) ProposalTallyResult() graphql1.ProposalTallyResultResolver {
 // ProposalVote returns graphql1.ProposalVoteResolver implementation.
 func (r *Resolver) ProposalVote() graphql1.ProposalVoteResolver { return &proposalVoteResolver{r} }
 
+type communityPoolSpendProposalContentResolver struct{ *Resolver }
+type parameterChangeResolver struct{ *Resolver }
 type proposalResolver struct{ *Resolver }
+type proposalDelegationVoteResolver struct{ *Resolver }
 type proposalDepositResolver struct{ *Resolver }
 type proposalTallyResultResolver struct{ *Resolver }
 type proposalVoteResolver struct{ *Resolver }
@@ -5,13 +5,16 @@ package resolvers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	pkgContext "github.com/oursky/likedao/pkg/context"
+	"github.com/oursky/likedao/pkg/dataloaders"
 	servererrors "github.com/oursky/likedao/pkg/errors"
 	graphql1 "github.com/oursky/likedao/pkg/generated/graphql"
 	"github.com/oursky/likedao/pkg/logging"
 	"github.com/oursky/likedao/pkg/models"
+	"github.com/oursky/likedao/pkg/queries"
 )
 
 func (r *mutationResolver) SetReaction(ctx context.Context, input models.SetReactionInput) (*models.Reaction, error) {
@@ -22,7 +25,37 @@ func (r *mutationResolver) SetReaction(ctx context.Context, input models.SetReac
 		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to unmarshal target type: %v", err))
 	}
 
-	res, err := pkgContext.GetMutatorsFromCtx(ctx).Reaction.SetReaction(input.TargetID.ID, targetType, userAddress, input.Reaction)
+	kind, err := pkgContext.GetQueriesFromCtx(ctx).Reaction.QueryReactionKind(targetType, input.Reaction)
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query reaction catalogue: %v", err))
+	}
+	if kind == nil || kind.Deprecated {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("reaction %s is not allowed for %s", input.Reaction, targetType))
+	}
+
+	targetID := input.TargetID.ID
+	switch targetType {
+	case models.ReactionTargetTypeComment:
+		comment, err := pkgContext.GetDataLoadersFromCtx(ctx).Comment.Load(input.TargetID.ID)
+		if err != nil {
+			return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to load comment: %v", err))
+		}
+		if comment == nil || comment.IsDeleted() {
+			return nil, servererrors.NotFound.NewError(ctx, fmt.Sprintf("comment %s not found", input.TargetID.ID))
+		}
+	case models.ReactionTargetTypePost:
+		// Reactions to posts target their ISCN IDs
+		post, err := pkgContext.GetDataLoadersFromCtx(ctx).Post.Load(input.TargetID.ID)
+		if err != nil {
+			return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to load post: %v", err))
+		}
+		if post == nil {
+			return nil, servererrors.NotFound.NewError(ctx, fmt.Sprintf("post %s not found", input.TargetID.ID))
+		}
+		targetID = post.ISCNID
+	}
+
+	res, err := pkgContext.GetMutatorsFromCtx(ctx).Reaction.SetReaction(targetID, targetType, userAddress, input.Reaction)
 	if err != nil {
 		return nil, servererrors.MutationError.NewError(ctx, fmt.Sprintf("failed to set reaction: %v", err))
 	}
@@ -38,7 +71,19 @@ func (r *mutationResolver) UnsetReaction(ctx context.Context, input models.Unset
 		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to unmarshal target type: %v", err))
 	}
 
-	res, err := pkgContext.GetMutatorsFromCtx(ctx).Reaction.UnsetReaction(input.TargetID.ID, targetType, userAddress)
+	targetID := input.TargetID.ID
+	if targetType == models.ReactionTargetTypePost {
+		post, err := pkgContext.GetDataLoadersFromCtx(ctx).Post.Load(input.TargetID.ID)
+		if err != nil {
+			return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to load post: %v", err))
+		}
+		if post == nil {
+			return nil, nil
+		}
+		targetID = post.ISCNID
+	}
+
+	res, err := pkgContext.GetMutatorsFromCtx(ctx).Reaction.UnsetReaction(targetID, targetType, userAddress)
 	if err != nil {
 		logger.Error(servererrors.MutationError.NewError(ctx, fmt.Sprintf("failed to unset reaction: %v", err)))
 		return nil, nil
@@ -47,8 +92,15 @@ func (r *mutationResolver) UnsetReaction(ctx context.Context, input models.Unset
 	return res, nil
 }
 
+func (r *queryResolver) ReactionCatalogue(ctx context.Context, targetType *models.ReactionTargetType) ([]models.ReactionKind, error) {
+	kinds, err := pkgContext.GetQueriesFromCtx(ctx).Reaction.QueryReactionKinds(targetType)
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query reaction catalogue: %v", err))
+	}
+	return kinds, nil
+}
+
 func (r *reactionResolver) Target(ctx context.Context, obj *models.Reaction) (models.Node, error) {
-	// TODO: Handle other target types
 	switch obj.TargetType {
 	case models.ReactionTargetTypeProposal:
 		proposal, err := pkgContext.GetDataLoadersFromCtx(ctx).Proposal.Load(obj.TargetID)
@@ -56,16 +108,90 @@ func (r *reactionResolver) Target(ctx context.Context, obj *models.Reaction) (mo
 			return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to load proposal: %v", err))
 		}
 		return proposal, nil
+	case models.ReactionTargetTypeComment:
+		comment, err := pkgContext.GetDataLoadersFromCtx(ctx).Comment.Load(obj.TargetID)
+		if err != nil {
+			return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to load comment: %v", err))
+		}
+		if comment == nil {
+			return nil, servererrors.NotFound.NewError(ctx, fmt.Sprintf("comment %s not found", obj.TargetID))
+		}
+		return comment, nil
+	case models.ReactionTargetTypePost:
+		post, err := pkgContext.GetDataLoadersFromCtx(ctx).Post.LoadPostByISCNID(obj.TargetID)
+		if err != nil {
+			return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to load post: %v", err))
+		}
+		if post == nil {
+			return nil, servererrors.NotFound.NewError(ctx, fmt.Sprintf("post %s not found", obj.TargetID))
+		}
+		return post, nil
 	default:
 		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("unsupported node type: %v", obj.TargetType))
 	}
 }
 
-// Mutation returns graphql1.MutationResolver implementation.
-func (r *Resolver) Mutation() graphql1.MutationResolver { return &mutationResolver{r} }
+func (r *reactionCountResolver) Stake(ctx context.Context, obj *models.ReactionCount) (models.BigInt, error) {
+	stake, err := pkgContext.GetDataLoadersFromCtx(ctx).Reaction.LoadReactorStake(dataloaders.ReactorAddressesKey{
+		TargetReactionKey: dataloaders.TargetReactionKey{TargetType: obj.TargetType, TargetID: obj.TargetID},
+		Reaction:          obj.Reaction,
+	})
+	if err != nil {
+		return "", servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query stake of reactors: %v", err))
+	}
+
+	return models.NewBigIntFromBunBigInt(*stake), nil
+}
+
+func (r *reactionCountResolver) Reactors(ctx context.Context, obj *models.ReactionCount, input models.QueryReactorsInput) (*models.Connection[models.Reaction], error) {
+	pagination, err := queries.NewPagination(input.First, input.After, input.Last, input.Before)
+	if err != nil {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid pagination: %v", err))
+	}
+
+	res, err := pkgContext.GetQueriesFromCtx(ctx).Reaction.
+		ScopeTargets(obj.TargetType, []string{obj.TargetID}).
+		QueryPaginatedReactions(obj.Reaction, pagination)
+	if errors.Is(err, queries.ErrInvalidCursor) {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid pagination: %v", err))
+	}
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query reactors: %v", err))
+	}
+
+	conn := models.NewConnection(res.Items, res.Cursors)
+	conn.TotalCount = res.PaginationInfo.TotalCount
+	conn.PageInfo.HasNextPage = res.PaginationInfo.HasNext
+	conn.PageInfo.HasPreviousPage = res.PaginationInfo.HasPrevious
+
+	return &conn, nil
+}
+
+func (r *reactorResolver) Validator(ctx context.Context, obj *models.Reaction) (*models.Validator, error) {
+	validator, err := pkgContext.GetDataLoadersFromCtx(ctx).Validator.LoadValidatorWithInfoBySelfDelegationAddress(obj.Address)
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query validator: %v", err))
+	}
+	return validator, nil
+}
+
+func (r *reactorResolver) Stake(ctx context.Context, obj *models.Reaction) (models.BigInt, error) {
+	stake, err := pkgContext.GetDataLoadersFromCtx(ctx).Account.LoadStakeByAddress(obj.Address)
+	if err != nil {
+		return "", servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query stake: %v", err))
+	}
+	return models.NewBigIntFromBunBigInt(*stake), nil
+}
 
 // Reaction returns graphql1.ReactionResolver implementation.
 func (r *Resolver) Reaction() graphql1.ReactionResolver { return &reactionResolver{r} }
 
-type mutationResolver struct{ *Resolver }
+// ReactionCount returns graphql1.ReactionCountResolver implementation.
+func (r *Resolver) ReactionCount() graphql1.ReactionCountResolver { return &reactionCountResolver{r} }
+
+// Reactor returns graphql1.ReactorResolver implementation.
+func (r *Resolver) Reactor() graphql1.ReactorResolver { return &reactorResolver{r} }
+
 type reactionResolver struct{ *Resolver }
+type reactionCountResolver struct{ *Resolver }
+type reactorResolver struct{ *Resolver }
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 
+	pkgContext "github.com/oursky/likedao/pkg/context"
 	"github.com/oursky/likedao/pkg/models"
 )
 
@@ -16,6 +17,41 @@ func (r *queryResolver) Node(ctx context.Context, id models.NodeID) (models.Node
 		return r.QueryTestByID(ctx, id)
 	case "block":
 		return r.BlockByID(ctx, id)
+	case "account":
+		return r.Account(ctx, id.ID)
+	case "session":
+		// Only active sessions of the authed user are exposed
+		if pkgContext.GetAuthedUserAddress(ctx) == "" {
+			return nil, nil
+		}
+		sessions, err := r.MySessions(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for i := range sessions {
+			if sessions[i].ID == id.ID {
+				return &sessions[i], nil
+			}
+		}
+		return nil, nil
+	case "transaction":
+		transaction, err := r.Transaction(ctx, id.ID)
+		if err != nil || transaction == nil {
+			return nil, err
+		}
+		return transaction, nil
+	case "comment":
+		comment, err := pkgContext.GetDataLoadersFromCtx(ctx).Comment.Load(id.ID)
+		if err != nil || comment == nil {
+			return nil, err
+		}
+		return comment, nil
+	case "post":
+		post, err := pkgContext.GetDataLoadersFromCtx(ctx).Post.Load(id.ID)
+		if err != nil || post == nil {
+			return nil, err
+		}
+		return post, nil
 	default:
 		panic(fmt.Sprintf(
 			`unknown entity type "%s"`,
@@ -1,6 +1,10 @@
 package resolvers
 
-import "github.com/uptrace/bun"
+import (
+	"github.com/oursky/likedao/pkg/config"
+	"github.com/oursky/likedao/pkg/models"
+	"github.com/uptrace/bun"
+)
 
 //go:generate go run github.com/99designs/gqlgen generate
 
@@ -11,4 +15,19 @@ import "github.com/uptrace/bun"
 type Resolver struct {
 	ServerDB *bun.DB
 	ChainDB  *bun.DB
+
+	latestBlocks    *poller[struct{}, *models.Block]
+	proposalTallies *poller[int, *models.ProposalTallyResult]
+	proposalVotes   *poller[int, *models.ProposalVote]
+}
+
+func NewResolver(config config.Config, serverDB *bun.DB, chainDB *bun.DB) *Resolver {
+	return &Resolver{
+		ServerDB: serverDB,
+		ChainDB:  chainDB,
+
+		latestBlocks:    newPoller(config, serverDB, chainDB, true, pollLatestBlock),
+		proposalTallies: newPoller(config, serverDB, chainDB, true, pollProposalTally),
+		proposalVotes:   newPoller(config, serverDB, chainDB, false, pollProposalVotes),
+	}
 }
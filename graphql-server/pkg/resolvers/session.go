@@ -0,0 +1,57 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"fmt"
+
+	pkgContext "github.com/oursky/likedao/pkg/context"
+	servererrors "github.com/oursky/likedao/pkg/errors"
+	graphql1 "github.com/oursky/likedao/pkg/generated/graphql"
+	"github.com/oursky/likedao/pkg/models"
+)
+
+func (r *mutationResolver) RevokeSession(ctx context.Context, input models.RevokeSessionInput) (*models.Session, error) {
+	userAddress := pkgContext.GetAuthedUserAddress(ctx)
+	if input.SessionID.EntityType != "session" {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid session id: %v", input.SessionID))
+	}
+
+	res, err := pkgContext.GetMutatorsFromCtx(ctx).Session.RevokeSession(input.SessionID.ID, userAddress)
+	if err != nil {
+		return nil, servererrors.MutationError.NewError(ctx, fmt.Sprintf("failed to revoke session: %v", err))
+	}
+
+	return res, nil
+}
+
+func (r *mutationResolver) RevokeAllSessions(ctx context.Context) ([]models.Session, error) {
+	userAddress := pkgContext.GetAuthedUserAddress(ctx)
+	res, err := pkgContext.GetMutatorsFromCtx(ctx).Session.RevokeAllSessions(userAddress)
+	if err != nil {
+		return nil, servererrors.MutationError.NewError(ctx, fmt.Sprintf("failed to revoke sessions: %v", err))
+	}
+
+	return res, nil
+}
+
+func (r *queryResolver) MySessions(ctx context.Context) ([]models.Session, error) {
+	userAddress := pkgContext.GetAuthedUserAddress(ctx)
+	res, err := pkgContext.GetQueriesFromCtx(ctx).Session.QueryActiveSessionsByAddress(userAddress)
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query sessions: %v", err))
+	}
+
+	return res, nil
+}
+
+func (r *sessionResolver) IsCurrent(ctx context.Context, obj *models.Session) (bool, error) {
+	return pkgContext.GetAuthedSessionID(ctx) == obj.ID, nil
+}
+
+// Session returns graphql1.SessionResolver implementation.
+func (r *Resolver) Session() graphql1.SessionResolver { return &sessionResolver{r} }
+
+type sessionResolver struct{ *Resolver }
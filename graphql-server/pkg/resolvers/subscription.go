@@ -0,0 +1,200 @@
+package resolvers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/oursky/likedao/pkg/config"
+	pkgContext "github.com/oursky/likedao/pkg/context"
+	"github.com/oursky/likedao/pkg/logging"
+	"github.com/oursky/likedao/pkg/models"
+	"github.com/uptrace/bun"
+)
+
+// Interval between polls of the chain database for subscriptions
+const subscriptionPollInterval = 5 * time.Second
+
+// Number of values a subscriber can fall behind before its subscription is ended
+const subscriberBufferSize = 64
+
+// pollFunc polls the chain database for a topic and returns the values to send to its subscribers
+type pollFunc[T any] func(ctx context.Context) ([]T, error)
+
+// poller shares one poll of the chain database per topic among all subscribers of the topic
+type poller[K comparable, T any] struct {
+	config   config.Config
+	serverDB *bun.DB
+	chainDB  *bun.DB
+	// Whether the last value of a topic is sent to new subscribers
+	replayLatest bool
+	// Creates the poll of a topic, called when the first subscriber of the topic subscribes
+	newPoll func(key K) pollFunc[T]
+
+	mu     sync.Mutex
+	topics map[K]*topic[T]
+}
+
+type topic[T any] struct {
+	subscribers map[chan T]struct{}
+	latest      T
+	hasLatest   bool
+}
+
+func newPoller[K comparable, T any](
+	config config.Config,
+	serverDB *bun.DB,
+	chainDB *bun.DB,
+	replayLatest bool,
+	newPoll func(key K) pollFunc[T],
+) *poller[K, T] {
+	return &poller[K, T]{
+		config:       config,
+		serverDB:     serverDB,
+		chainDB:      chainDB,
+		replayLatest: replayLatest,
+		newPoll:      newPoll,
+		topics:       make(map[K]*topic[T]),
+	}
+}
+
+// Subscribe returns a channel of the values polled for key, the channel is closed when ctx is done
+func (p *poller[K, T]) Subscribe(ctx context.Context, key K) <-chan T {
+	ch := make(chan T, subscriberBufferSize)
+
+	p.mu.Lock()
+	t, ok := p.topics[key]
+	if !ok {
+		t = &topic[T]{subscribers: make(map[chan T]struct{})}
+		p.topics[key] = t
+		go p.run(key, t)
+	}
+	t.subscribers[ch] = struct{}{}
+	if p.replayLatest && t.hasLatest {
+		ch <- t.latest
+	}
+	p.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.unsubscribe(t, ch)
+	}()
+
+	return ch
+}
+
+// unsubscribe must be called with mu held
+func (p *poller[K, T]) unsubscribe(t *topic[T], ch chan T) {
+	if _, ok := t.subscribers[ch]; !ok {
+		return
+	}
+	delete(t.subscribers, ch)
+	close(ch)
+}
+
+// run polls the topic immediately and then on every interval until it has no subscribers.
+// Data loaders cache results for the lifetime of a context, so each poll is given a fresh request context
+func (p *poller[K, T]) run(key K, t *topic[T]) {
+	poll := p.newPoll(key)
+
+	ticker := time.NewTicker(subscriptionPollInterval)
+	defer ticker.Stop()
+
+	for {
+		p.mu.Lock()
+		if len(t.subscribers) == 0 {
+			delete(p.topics, key)
+			p.mu.Unlock()
+			return
+		}
+		p.mu.Unlock()
+
+		ctx := pkgContext.NewRequestContext(context.Background(), p.serverDB, p.chainDB, p.config)
+		values, err := poll(ctx)
+		if err != nil {
+			logging.GetLogger(ctx).Errorf("failed to poll subscription: %v", err)
+		}
+
+		p.mu.Lock()
+		for _, value := range values {
+			t.latest, t.hasLatest = value, true
+			for ch := range t.subscribers {
+				select {
+				case ch <- value:
+				default:
+					// Subscriber falling behind would hold up every other subscriber of the topic
+					p.unsubscribe(t, ch)
+				}
+			}
+		}
+		p.mu.Unlock()
+
+		<-ticker.C
+	}
+}
+
+// pollLatestBlock returns the latest block whenever a new block is indexed
+func pollLatestBlock(struct{}) pollFunc[*models.Block] {
+	lastHeight := 0
+	return func(ctx context.Context) ([]*models.Block, error) {
+		block, err := pkgContext.GetQueriesFromCtx(ctx).Block.QueryLatestBlock()
+		if err != nil {
+			return nil, err
+		}
+		if block.Height <= lastHeight {
+			return nil, nil
+		}
+
+		lastHeight = block.Height
+		return []*models.Block{block}, nil
+	}
+}
+
+// pollProposalTally returns the tally result of the proposal whenever it changes
+func pollProposalTally(proposalID int) pollFunc[*models.ProposalTallyResult] {
+	var lastTally *models.ProposalTallyResult
+	return func(ctx context.Context) ([]*models.ProposalTallyResult, error) {
+		tally, err := pkgContext.GetDataLoadersFromCtx(ctx).Proposal.LoadProposalTallyResult(proposalID)
+		if err != nil {
+			return nil, err
+		}
+		if tally == nil || (lastTally != nil && lastTally.Equals(*tally)) {
+			return nil, nil
+		}
+
+		lastTally = tally
+		return []*models.ProposalTallyResult{tally}, nil
+	}
+}
+
+// pollProposalVotes returns the votes of the proposal indexed since the last poll,
+// votes indexed before the first poll are skipped
+func pollProposalVotes(proposalID int) pollFunc[*models.ProposalVote] {
+	var lastHeight *int64
+	return func(ctx context.Context) ([]*models.ProposalVote, error) {
+		queries := pkgContext.GetQueriesFromCtx(ctx)
+		if lastHeight == nil {
+			latestBlock, err := queries.Block.QueryLatestBlock()
+			if err != nil {
+				return nil, err
+			}
+			height := int64(latestBlock.Height)
+			lastHeight = &height
+			return nil, nil
+		}
+
+		votes, err := queries.Proposal.QueryProposalVotesAfterHeight(proposalID, *lastHeight)
+		if err != nil {
+			return nil, err
+		}
+
+		res := make([]*models.ProposalVote, 0, len(votes))
+		for i := range votes {
+			res = append(res, &votes[i])
+			*lastHeight = votes[i].Height
+		}
+		return res, nil
+	}
+}
@@ -0,0 +1,166 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/forbole/bdjuno/database/types"
+	pkgContext "github.com/oursky/likedao/pkg/context"
+	servererrors "github.com/oursky/likedao/pkg/errors"
+	graphql1 "github.com/oursky/likedao/pkg/generated/graphql"
+	"github.com/oursky/likedao/pkg/models"
+	"github.com/oursky/likedao/pkg/queries"
+)
+
+func (r *msgDelegateResolver) Validator(ctx context.Context, obj *models.MsgDelegate) (*models.Validator, error) {
+	validator, err := pkgContext.GetDataLoadersFromCtx(ctx).Validator.LoadValidatorWithInfoByOperatorAddress(obj.ValidatorAddress)
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query validator: %v", err))
+	}
+	return validator, nil
+}
+
+func (r *msgDelegateResolver) Amount(ctx context.Context, obj *models.MsgDelegate) (*types.DbDecCoin, error) {
+	amount := toCoin(obj.Amount)
+	return &amount, nil
+}
+
+func (r *msgDepositResolver) Proposal(ctx context.Context, obj *models.MsgDeposit) (*models.Proposal, error) {
+	proposal, err := pkgContext.GetDataLoadersFromCtx(ctx).Proposal.Load(strconv.Itoa(obj.ProposalID))
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query proposal: %v", err))
+	}
+	return proposal, nil
+}
+
+func (r *msgDepositResolver) Amount(ctx context.Context, obj *models.MsgDeposit) ([]types.DbDecCoin, error) {
+	return toCoinList(obj.Amount), nil
+}
+
+func (r *msgSendResolver) Amount(ctx context.Context, obj *models.MsgSend) ([]types.DbDecCoin, error) {
+	return toCoinList(obj.Amount), nil
+}
+
+func (r *msgSubmitProposalResolver) InitialDeposit(ctx context.Context, obj *models.MsgSubmitProposal) ([]types.DbDecCoin, error) {
+	return toCoinList(obj.InitialDeposit), nil
+}
+
+func (r *msgSubmitProposalResolver) ContentType(ctx context.Context, obj *models.MsgSubmitProposal) (string, error) {
+	return strings.TrimPrefix(obj.Content.Type, "/"), nil
+}
+
+func (r *msgSubmitProposalResolver) Title(ctx context.Context, obj *models.MsgSubmitProposal) (string, error) {
+	return obj.Content.Title, nil
+}
+
+func (r *msgSubmitProposalResolver) Description(ctx context.Context, obj *models.MsgSubmitProposal) (string, error) {
+	return obj.Content.Description, nil
+}
+
+func (r *msgVoteResolver) Proposal(ctx context.Context, obj *models.MsgVote) (*models.Proposal, error) {
+	proposal, err := pkgContext.GetDataLoadersFromCtx(ctx).Proposal.Load(strconv.Itoa(obj.ProposalID))
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query proposal: %v", err))
+	}
+	return proposal, nil
+}
+
+func (r *queryResolver) Transaction(ctx context.Context, hash string) (*models.Transaction, error) {
+	transaction, err := pkgContext.GetDataLoadersFromCtx(ctx).Transaction.LoadTransactionByHash(hash)
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query transaction: %v", err))
+	}
+	return transaction, nil
+}
+
+func (r *queryResolver) Transactions(ctx context.Context, input models.QueryTransactionsInput) (*models.Connection[models.Transaction], error) {
+	pagination, err := queries.NewPagination(input.First, input.After, input.Last, input.Before)
+	if err != nil {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid pagination: %v", err))
+	}
+
+	transactionQuery := pkgContext.GetQueriesFromCtx(ctx).Transaction.ScopeTransactionAddress(input.Address)
+	res, err := transactionQuery.QueryPaginatedTransactions(pagination)
+	if errors.Is(err, queries.ErrInvalidCursor) {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid pagination: %v", err))
+	}
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query transactions: %v", err))
+	}
+
+	conn := models.NewConnection(res.Items, res.Cursors)
+	// Counting goes through all messages of the address, so it is skipped unless requested
+	if isFieldRequested(ctx, "totalCount") {
+		conn.TotalCount, err = transactionQuery.QueryTransactionCount()
+		if err != nil {
+			return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to count transactions: %v", err))
+		}
+	}
+	conn.PageInfo.HasNextPage = res.PaginationInfo.HasNext
+	conn.PageInfo.HasPreviousPage = res.PaginationInfo.HasPrevious
+
+	return &conn, nil
+}
+
+func (r *transactionResolver) Block(ctx context.Context, obj *models.Transaction) (*models.Block, error) {
+	block, err := pkgContext.GetDataLoadersFromCtx(ctx).Block.LoadBlockByHeight(obj.Height)
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query transaction block: %v", err))
+	}
+	return block, nil
+}
+
+func (r *transactionResolver) Fee(ctx context.Context, obj *models.Transaction) ([]types.DbDecCoin, error) {
+	return toCoinList(obj.Fee.Amount), nil
+}
+
+func (r *transactionResolver) Messages(ctx context.Context, obj *models.Transaction) ([]models.TransactionMessage, error) {
+	messages, err := pkgContext.GetDataLoadersFromCtx(ctx).Transaction.LoadMessagesByTransactionHash(obj.Hash)
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query transaction messages: %v", err))
+	}
+	return messages, nil
+}
+
+func (r *transactionMessageResolver) RawValue(ctx context.Context, obj *models.TransactionMessage) (string, error) {
+	return string(obj.Value), nil
+}
+
+// MsgDelegate returns graphql1.MsgDelegateResolver implementation.
+func (r *Resolver) MsgDelegate() graphql1.MsgDelegateResolver { return &msgDelegateResolver{r} }
+
+// MsgDeposit returns graphql1.MsgDepositResolver implementation.
+func (r *Resolver) MsgDeposit() graphql1.MsgDepositResolver { return &msgDepositResolver{r} }
+
+// MsgSend returns graphql1.MsgSendResolver implementation.
+func (r *Resolver) MsgSend() graphql1.MsgSendResolver { return &msgSendResolver{r} }
+
+// MsgSubmitProposal returns graphql1.MsgSubmitProposalResolver implementation.
+func (r *Resolver) MsgSubmitProposal() graphql1.MsgSubmitProposalResolver {
+	return &msgSubmitProposalResolver{r}
+}
+
+// MsgVote returns graphql1.MsgVoteResolver implementation.
+func (r *Resolver) MsgVote() graphql1.MsgVoteResolver { return &msgVoteResolver{r} }
+
+// Transaction returns graphql1.TransactionResolver implementation.
+func (r *Resolver) Transaction() graphql1.TransactionResolver { return &transactionResolver{r} }
+
+// TransactionMessage returns graphql1.TransactionMessageResolver implementation.
+func (r *Resolver) TransactionMessage() graphql1.TransactionMessageResolver {
+	return &transactionMessageResolver{r}
+}
+
+type msgDelegateResolver struct{ *Resolver }
+type msgDepositResolver struct{ *Resolver }
+type msgSendResolver struct{ *Resolver }
+type msgSubmitProposalResolver struct{ *Resolver }
+type msgVoteResolver struct{ *Resolver }
+type transactionResolver struct{ *Resolver }
+type transactionMessageResolver struct{ *Resolver }
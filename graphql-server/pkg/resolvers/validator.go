@@ -5,41 +5,50 @@ package resolvers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	pkgContext "github.com/oursky/likedao/pkg/context"
 	servererrors "github.com/oursky/likedao/pkg/errors"
 	graphql1 "github.com/oursky/likedao/pkg/generated/graphql"
 	"github.com/oursky/likedao/pkg/models"
+	"github.com/oursky/likedao/pkg/queries"
 )
 
 func (r *queryResolver) Validators(ctx context.Context, input models.QueryValidatorsInput) (*models.Connection[models.Validator], error) {
+	pagination, err := queries.NewOffsetPagination(input.First, input.After, input.Last, input.Before, input.Offset)
+	if err != nil {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid pagination: %v", err))
+	}
+
 	validatorQuery := pkgContext.GetQueriesFromCtx(ctx).Validator
 
 	if input.Status != nil {
 		validatorQuery = validatorQuery.ScopeValidatorStatus(*input.Status)
 	}
 
+	if input.SearchTerm != nil && *input.SearchTerm != "" {
+		validatorQuery = validatorQuery.ScopeValidatorSearchTerm(*input.SearchTerm)
+	}
+
 	if input.Order != nil {
 		validatorQuery = validatorQuery.ValidatorOrderBy(*input.Order)
 	}
 
-	res, err := validatorQuery.QueryPaginatedValidators(input.First, input.After, []string{})
+	res, err := validatorQuery.QueryPaginatedValidators(pagination, []string{})
+	if errors.Is(err, queries.ErrInvalidCursor) {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid pagination: %v", err))
+	}
 	if err != nil {
 		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query validators: %v", err))
 	}
 
-	validatorCursorMap := make(map[string]string)
-	for _, validator := range res.Items {
-		cursorString := validator.ConsensusAddress
-		validatorCursorMap[validator.NodeID().String()] = cursorString
-	}
-
-	conn := models.NewConnection(res.Items, func(model models.Validator) string {
-		return validatorCursorMap[model.NodeID().String()]
-	})
-
+	conn := models.NewConnection(res.Items, res.Cursors)
 	conn.TotalCount = res.PaginationInfo.TotalCount
 	conn.PageInfo.HasNextPage = res.PaginationInfo.HasNext
 	conn.PageInfo.HasPreviousPage = res.PaginationInfo.HasPrevious
@@ -47,6 +56,33 @@ func (r *queryResolver) Validators(ctx context.Context, input models.QueryValida
 	return &conn, nil
 }
 
+func (r *queryResolver) Validator(ctx context.Context, address string) (*models.Validator, error) {
+	config := pkgContext.GetConfigFromCtx(ctx)
+	validatorLoader := pkgContext.GetDataLoadersFromCtx(ctx).Validator
+
+	prefix, _, err := bech32.DecodeAndConvert(address)
+	if err != nil {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid validator address: %s", address))
+	}
+
+	var validator *models.Validator
+	switch prefix {
+	case config.Chain.Bech32Prefix + sdk.PrefixValidator + sdk.PrefixOperator:
+		validator, err = validatorLoader.LoadValidatorWithInfoByOperatorAddress(address)
+	case config.Chain.Bech32Prefix + sdk.PrefixValidator + sdk.PrefixConsensus:
+		validator, err = validatorLoader.LoadValidatorWithInfoByConsensusAddress(address)
+	case config.Chain.Bech32Prefix:
+		validator, err = validatorLoader.LoadValidatorWithInfoBySelfDelegationAddress(address)
+	default:
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid validator address: %s", address))
+	}
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query validator: %v", err))
+	}
+
+	return validator, nil
+}
+
 func (r *validatorResolver) OperatorAddress(ctx context.Context, obj *models.Validator) (*string, error) {
 	// Using dataloader here as we cannot assume the incoming validator has the info and description loaded
 	validator, err := pkgContext.GetDataLoadersFromCtx(ctx).Validator.LoadValidatorWithInfoByConsensusAddress(obj.ConsensusAddress)
@@ -285,7 +321,95 @@ func (r *validatorResolver) RelativeTotalProposalCount(ctx context.Context, obj
 	return *count, nil
 }
 
+func (r *validatorResolver) GovernanceRecord(ctx context.Context, obj *models.Validator, input models.QueryValidatorGovernanceRecordInput) (*models.ValidatorGovernanceRecordConnection, error) {
+	pagination, err := queries.NewPagination(input.First, input.After, input.Last, input.Before)
+	if err != nil {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid pagination: %v", err))
+	}
+
+	record, err := pkgContext.GetDataLoadersFromCtx(ctx).Validator.LoadGovernanceRecordByConsensusAddress(obj.ConsensusAddress)
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query governance record: %v", err))
+	}
+
+	res, err := queries.PaginateItems(record.Entries, pagination, func(entry models.ValidatorGovernanceRecordEntry) []string {
+		return []string{strconv.Itoa(entry.ProposalID)}
+	})
+	if errors.Is(err, queries.ErrInvalidCursor) {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid pagination: %v", err))
+	}
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to paginate governance record: %v", err))
+	}
+
+	conn := models.NewConnection(res.Items, res.Cursors)
+	conn.TotalCount = res.PaginationInfo.TotalCount
+	conn.PageInfo.HasNextPage = res.PaginationInfo.HasNext
+	conn.PageInfo.HasPreviousPage = res.PaginationInfo.HasPrevious
+
+	return &models.ValidatorGovernanceRecordConnection{
+		Connection:                conn,
+		ValidatorGovernanceRecord: *record,
+	}, nil
+}
+
+func (r *validatorResolver) History(ctx context.Context, obj *models.Validator, rangeArg models.ValidatorHistoryRange, interval models.ValidatorHistoryInterval) ([]models.ValidatorSnapshot, error) {
+	to := time.Now()
+	if rangeArg.To != nil {
+		to = *rangeArg.To
+	}
+	if !rangeArg.From.Before(to) {
+		return nil, servererrors.BadUserInput.NewError(ctx, "invalid range: from must be before to")
+	}
+	if to.Sub(rangeArg.From)/interval.Duration() > models.MaxValidatorHistoryBuckets {
+		return nil, servererrors.BadUserInput.NewError(ctx, fmt.Sprintf("invalid range: more than %d buckets in range", models.MaxValidatorHistoryBuckets))
+	}
+
+	snapshots, err := pkgContext.GetQueriesFromCtx(ctx).ValidatorSnapshot.QueryValidatorHistory(obj.ConsensusAddress, rangeArg.From, to, interval)
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query validator history: %v", err))
+	}
+	return snapshots, nil
+}
+
+func (r *validatorGovernanceRecordEntryResolver) Proposal(ctx context.Context, obj *models.ValidatorGovernanceRecordEntry) (*models.Proposal, error) {
+	proposal, err := pkgContext.GetDataLoadersFromCtx(ctx).Proposal.Load(strconv.Itoa(obj.ProposalID))
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query proposal: %v", err))
+	}
+	return proposal, nil
+}
+
+func (r *validatorGovernanceRecordEntryResolver) Vote(ctx context.Context, obj *models.ValidatorGovernanceRecordEntry) (*models.ProposalVote, error) {
+	if !obj.Voted() {
+		return nil, nil
+	}
+
+	key := models.ProposalVoteKey{ProposalID: obj.ProposalID, Address: obj.VoterAddress}
+	vote, err := pkgContext.GetDataLoadersFromCtx(ctx).Proposal.LoadProposalVote(key)
+	if err != nil {
+		return nil, servererrors.QueryError.NewError(ctx, fmt.Sprintf("failed to query proposal vote: %v", err))
+	}
+	return vote, nil
+}
+
+func (r *validatorHistoryBucketResolver) VotingPowerUnits(ctx context.Context, obj *models.ValidatorSnapshot) (models.BigInt, error) {
+	return models.NewBigIntFromBunBigInt(obj.VotingPower), nil
+}
+
 // Validator returns graphql1.ValidatorResolver implementation.
 func (r *Resolver) Validator() graphql1.ValidatorResolver { return &validatorResolver{r} }
 
+// ValidatorGovernanceRecordEntry returns graphql1.ValidatorGovernanceRecordEntryResolver implementation.
+func (r *Resolver) ValidatorGovernanceRecordEntry() graphql1.ValidatorGovernanceRecordEntryResolver {
+	return &validatorGovernanceRecordEntryResolver{r}
+}
+
+// ValidatorHistoryBucket returns graphql1.ValidatorHistoryBucketResolver implementation.
+func (r *Resolver) ValidatorHistoryBucket() graphql1.ValidatorHistoryBucketResolver {
+	return &validatorHistoryBucketResolver{r}
+}
+
 type validatorResolver struct{ *Resolver }
+type validatorGovernanceRecordEntryResolver struct{ *Resolver }
+type validatorHistoryBucketResolver struct{ *Resolver }
@@ -22,7 +22,7 @@ export function useCommunityStatusQuery(): RequestState<OverviewScreenModel> {
   >(OverviewScreenQuery, {
     variables: {
       first: 4,
-      after: 0,
+      offset: 0,
       order: {
         submitTime: Sort.Desc,
       },
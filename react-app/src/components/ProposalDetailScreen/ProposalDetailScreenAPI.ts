@@ -119,7 +119,7 @@ export const useProposalVotesQuery: UseProposalVotesQuery = (
     variables: {
       proposalId,
       input: {
-        after: initialOffset,
+        offset: initialOffset,
         first: pageSize,
         order: {},
       },
@@ -163,7 +163,7 @@ export const useProposalVotesQuery: UseProposalVotesQuery = (
           proposalId,
           input: {
             first,
-            after,
+            offset: after,
             pinnedValidators: delegatedValidators,
             order: getProposalVoteSortOrderVariable(order),
           },
@@ -264,7 +264,7 @@ export const useProposalDepositsQuery: UseProposalDepositsQuery = (
     variables: {
       proposalId,
       input: {
-        after: initialOffset,
+        offset: initialOffset,
         first: pageSize,
         order: {},
       },
@@ -309,7 +309,7 @@ export const useProposalDepositsQuery: UseProposalDepositsQuery = (
           proposalId,
           input: {
             first,
-            after,
+            offset: after,
             order: getProposalDepositSortOrderVariable(order),
             pinnedValidators: delegatedValidators,
           },
@@ -123,7 +123,7 @@ const useProposalHistoryQuery = (): ProposalHistoryQuery => {
       fetch({
         variables: {
           first,
-          after,
+          offset: after,
           order: {
             submitTime: Sort.Asc,
           },
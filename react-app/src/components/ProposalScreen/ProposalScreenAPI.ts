@@ -14,7 +14,7 @@ import { PaginatedProposals } from "./ProposalScreenModel";
 
 type ProposalFilter = Omit<
   ProposalScreenQueryQueryVariables,
-  "first" | "after"
+  "first" | "offset"
 >;
 
 export type FilterKey =
@@ -76,7 +76,7 @@ export const useProposalsQuery: UseProposalsQuery = (
     ProposalScreenQueryQueryVariables
   >(ProposalScreenQuery, {
     variables: {
-      after: initialOffset,
+      offset: initialOffset,
       first: pageSize,
     },
     fetchPolicy: "cache-and-network",
@@ -102,7 +102,7 @@ export const useProposalsQuery: UseProposalsQuery = (
       fetch({
         variables: {
           first,
-          after,
+          offset: after,
           ...getFilterVariables(tab, address),
         },
       });
@@ -13,7 +13,7 @@ import { PaginatedValidators } from "./ValidatorScreenModel";
 
 type ValidatorFilter = Omit<
   ValidatorScreenQueryQueryVariables,
-  "first" | "after" | "order"
+  "first" | "offset" | "order"
 >;
 
 export type FilterKey = "all" | "active" | "inactive";
@@ -95,7 +95,7 @@ export const useValidatorsQuery: UseValidatorQuery = (
     ValidatorScreenQueryQueryVariables
   >(ValidatorScreenQuery, {
     variables: {
-      after: initialOffset,
+      offset: initialOffset,
       first: pageSize,
     },
     fetchPolicy: "cache-and-network",
@@ -122,7 +122,7 @@ export const useValidatorsQuery: UseValidatorQuery = (
       fetch({
         variables: {
           first,
-          after,
+          offset: after,
           order: getValidatorSortOrderVariable(order),
           ...getValidatorFilterVariables(filter),
         },